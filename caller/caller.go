@@ -1,23 +1,26 @@
 package caller
 
 import (
+	"context"
 	"fmt"
 	syslog "log"
+	"os"
 	"runtime"
-	"strings"
 
+	"github.com/prakashpandey/golog/internal/stack"
 	"github.com/prakashpandey/golog/log"
 )
 
+// errorLogger reports failures to collect caller information. It writes to
+// os.Stderr rather than the standard library's default logger, which may be
+// redirected to a golog Logger with log.RedirectStdLog and would recurse.
+var errorLogger = syslog.New(os.Stderr, "golog: ", syslog.LstdFlags)
+
 // Caller represents the information about the caller.
-type Caller struct {
-	File     string
-	Line     int
-	Function string
-}
+type Caller = stack.Caller
 
 // StackTrace represents a stack trace.
-type StackTrace []Caller
+type StackTrace = stack.StackTrace
 
 // GetCaller returns the caller information for the given skip level.
 func GetCaller(skip int) (Caller, error) {
@@ -34,10 +37,6 @@ func GetCaller(skip int) (Caller, error) {
 	}, nil
 }
 
-func (c Caller) String() string {
-	return fmt.Sprintf("%s:%d %s", c.File, c.Line, c.Function)
-}
-
 // GetStackTrace returns the stack trace starting from the given skip level.
 func GetStackTrace(skip int) (StackTrace, error) {
 	var stackTrace StackTrace
@@ -57,33 +56,77 @@ func GetStackTrace(skip int) (StackTrace, error) {
 	return stackTrace, nil
 }
 
-// String returns a formatted string representation of the stack trace.
-func (st StackTrace) String() string {
-	var builder strings.Builder
-	for _, callerInfo := range st {
-		builder.WriteString(fmt.Sprintf("%s:%d %s\n", callerInfo.File, callerInfo.Line, callerInfo.Function))
+// CallerForPC returns the caller information for a program counter as
+// returned by runtime.Callers.
+func CallerForPC(pc uintptr) Caller {
+	frame, _ := runtime.CallersFrames([]uintptr{pc}).Next()
+	return Caller{
+		File:     frame.File,
+		Line:     frame.Line,
+		Function: frame.Function,
 	}
-	return builder.String()
 }
 
-// stacktrace returns the keys and values with caller and stack trace information.
+// GetStackTraceFromPC returns the stack trace of the current goroutine starting
+// at the frame of pc, a program counter of a caller as returned by runtime.Callers
+// or the PC of a runtime.Frame, which is one less. It falls back to GetStackTrace(skip) if pc is not on the current stack.
+func GetStackTraceFromPC(pc uintptr, skip int) (StackTrace, error) {
+	pcs := stack.Callers(skip + 1)
+	for i := range pcs {
+		if pcs[i] == pc || pcs[i] == pc+1 {
+			return stack.FromPCs(pcs[i:]), nil
+		}
+	}
+	return GetStackTrace(skip + 1)
+}
+
+// AddStacktrace returns the keys and values with caller and stack trace information.
+// config.Caller.Skip is the number of stack frames to skip above the function calling AddStacktrace.
 // It appends caller and stack trace information to the keys and values if enabled.
 // Caller information is appended only if enabled.
 // Stack trace information is appended only if enabled and the log level is greater than or equal to the stack trace level.
 func AddStacktrace(level log.Level, config log.Config, keysAndValues []any) []any {
+	config.Caller.Skip++ // addStacktrace
+	return addStacktrace(0, level, config, keysAndValues)
+}
+
+// AddStacktraceContext is like AddStacktrace, but if ctx carries a caller stored
+// with log.ContextWithCaller, the caller and stack trace start at that caller
+// instead of skipping config.Caller.Skip stack frames.
+func AddStacktraceContext(ctx context.Context, level log.Level, config log.Config, keysAndValues []any) []any {
+	pc, _ := log.CallerFromContext(ctx)
+	config.Caller.Skip++ // addStacktrace
+	return addStacktrace(pc, level, config, keysAndValues)
+}
+
+// addStacktrace implements AddStacktrace for the caller pc, or for the caller
+// config.Caller.Skip frames above its caller if pc is 0.
+func addStacktrace(pc uintptr, level log.Level, config log.Config, keysAndValues []any) []any {
 	if config.Caller.Enabled {
-		c, err := GetCaller(config.Caller.Skip + 1)
+		var c Caller
+		var err error
+		if pc != 0 {
+			c = CallerForPC(pc)
+		} else {
+			c, err = GetCaller(config.Caller.Skip + 1)
+		}
 		if err != nil {
-			syslog.Default().Printf("failed to get caller info: %v", err)
+			errorLogger.Printf("failed to get caller info: %v", err)
 		}
 		// Append caller information to the keys and values at the start of the slice.
 		keysAndValues = append([]any{config.Caller.FieldName, c.String()}, keysAndValues...)
 	}
 
 	if config.Stacktrace.Enabled && level >= config.Stacktrace.Level {
-		st, err := GetStackTrace(config.Caller.Skip + 1)
+		var st StackTrace
+		var err error
+		if pc != 0 {
+			st, err = GetStackTraceFromPC(pc, config.Caller.Skip+1)
+		} else {
+			st, err = GetStackTrace(config.Caller.Skip + 1)
+		}
 		if err != nil {
-			syslog.Default().Printf("failed to get stack trace: %v", err)
+			errorLogger.Printf("failed to get stack trace: %v", err)
 		}
 		keysAndValues = append([]any{config.Stacktrace.FieldName, st.String()}, keysAndValues...)
 	}
@@ -2,6 +2,7 @@ package caller
 
 import (
 	"fmt"
+	"runtime"
 	"testing"
 )
 
@@ -56,3 +57,24 @@ func TestGetStackTraceWithSkip(t *testing.T) {
 
 	fmt.Printf("StackTrace with skip:\n%s", stackTrace.String())
 }
+
+// stackTraceFromCaller returns the program counter of its caller and the stack trace starting there.
+func stackTraceFromCaller() (uintptr, StackTrace, error) {
+	pcs := make([]uintptr, 1)
+	runtime.Callers(2, pcs)
+	st, err := GetStackTraceFromPC(pcs[0], 0)
+	return pcs[0], st, err
+}
+
+func TestCallerForPC(t *testing.T) {
+	pc, st, err := stackTraceFromCaller()
+	_, file, line, _ := runtime.Caller(0)
+
+	c := CallerForPC(pc)
+	if c.File != file || c.Line != line-1 {
+		t.Errorf("Expected caller %s:%d, got: %+v", file, line-1, c)
+	}
+	if err != nil || len(st) == 0 || st[0] != c {
+		t.Errorf("Expected stack trace to start at %+v, got: %v (%v)", c, st, err)
+	}
+}
@@ -0,0 +1,61 @@
+// Package stack holds the stack trace types shared by the log and caller packages,
+// which cannot import each other.
+package stack
+
+import (
+	"fmt"
+	"runtime"
+	"strings"
+)
+
+// Caller represents the information about the caller.
+type Caller struct {
+	File     string
+	Line     int
+	Function string
+}
+
+func (c Caller) String() string {
+	return fmt.Sprintf("%s:%d %s", c.File, c.Line, c.Function)
+}
+
+// StackTrace represents a stack trace.
+type StackTrace []Caller
+
+// String returns a formatted string representation of the stack trace.
+func (st StackTrace) String() string {
+	var builder strings.Builder
+	for _, callerInfo := range st {
+		builder.WriteString(fmt.Sprintf("%s:%d %s\n", callerInfo.File, callerInfo.Line, callerInfo.Function))
+	}
+	return builder.String()
+}
+
+// Callers returns the program counters of the current goroutine's stack, skipping
+// skip frames above the function calling Callers.
+func Callers(skip int) []uintptr {
+	pcs := make([]uintptr, 64)
+	for {
+		n := runtime.Callers(skip+2, pcs)
+		if n < len(pcs) {
+			return pcs[:n]
+		}
+		pcs = make([]uintptr, 2*len(pcs))
+	}
+}
+
+// FromPCs returns the stack trace of program counters as returned by runtime.Callers.
+func FromPCs(pcs []uintptr) StackTrace {
+	if len(pcs) == 0 {
+		return nil
+	}
+	var stackTrace StackTrace
+	frames := runtime.CallersFrames(pcs)
+	for {
+		frame, more := frames.Next()
+		stackTrace = append(stackTrace, Caller{File: frame.File, Line: frame.Line, Function: frame.Function})
+		if !more {
+			return stackTrace
+		}
+	}
+}
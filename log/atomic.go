@@ -0,0 +1,111 @@
+package log
+
+import (
+	"io"
+	"sort"
+	"sync"
+	"sync/atomic"
+)
+
+// AtomicLevel is a minimum log level that can be changed while loggers are using it.
+// Reads are lock-free, so loggers consult it on every call.
+// The zero value is unset, in which case loggers fall back to Config.LogLevel.
+// It is safe for concurrent use.
+type AtomicLevel struct {
+	v atomic.Int32
+}
+
+// NewAtomicLevel returns an AtomicLevel set to level.
+func NewAtomicLevel(level Level) *AtomicLevel {
+	a := &AtomicLevel{}
+	a.SetLevel(level)
+	return a
+}
+
+// Level returns the current level.
+func (a *AtomicLevel) Level() Level {
+	return Level(a.v.Load())
+}
+
+// SetLevel changes the level for all loggers sharing a.
+func (a *AtomicLevel) SetLevel(level Level) {
+	a.v.Store(int32(level))
+}
+
+// AtomicAttrs is a set of attributes logged with each entry that can be replaced
+// while loggers are using it. It is safe for concurrent use.
+type AtomicAttrs struct {
+	v atomic.Pointer[[]any]
+}
+
+// NewAtomicAttrs returns AtomicAttrs holding attrs.
+func NewAtomicAttrs(attrs map[string]string) *AtomicAttrs {
+	a := &AtomicAttrs{}
+	a.Store(attrs)
+	return a
+}
+
+// Store replaces the attributes for all loggers sharing a.
+func (a *AtomicAttrs) Store(attrs map[string]string) {
+	keys := make([]string, 0, len(attrs))
+	for k := range attrs {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	keysAndValues := make([]any, 0, 2*len(keys))
+	for _, k := range keys {
+		keysAndValues = append(keysAndValues, k, attrs[k])
+	}
+	a.v.Store(&keysAndValues)
+}
+
+// KeysAndValues returns the attributes as keys and values sorted by key.
+// The returned slice must not be modified.
+func (a *AtomicAttrs) KeysAndValues() []any {
+	if keysAndValues := a.v.Load(); keysAndValues != nil {
+		return *keysAndValues
+	}
+	return nil
+}
+
+// AtomicWriter is an io.Writer writing to a set of outputs that can be replaced
+// while loggers are using it. It is safe for concurrent use.
+type AtomicWriter struct {
+	mu      sync.RWMutex
+	w       io.Writer
+	outputs []io.Writer
+}
+
+// NewAtomicWriter returns an AtomicWriter writing to outputs.
+func NewAtomicWriter(outputs ...io.Writer) *AtomicWriter {
+	w := &AtomicWriter{}
+	w.Swap(outputs...)
+	return w
+}
+
+// Write writes p to all outputs.
+func (w *AtomicWriter) Write(p []byte) (int, error) {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	return w.w.Write(p)
+}
+
+// Swap replaces the outputs and returns the previous ones.
+// It waits for writes in progress, so the previous outputs can be closed safely.
+func (w *AtomicWriter) Swap(outputs ...io.Writer) []io.Writer {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	prev := w.outputs
+	w.outputs = outputs
+	w.w = io.MultiWriter(outputs...)
+	return prev
+}
+
+// Sync flushes the current outputs with SyncOutputs. The outputs are owned by
+// whoever swapped them in, so AtomicWriter has no Close method and CloseOutputs
+// leaves it open.
+func (w *AtomicWriter) Sync() error {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	return SyncOutputs(w.outputs)
+}
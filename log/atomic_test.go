@@ -0,0 +1,107 @@
+package log
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+	"sync"
+	"testing"
+)
+
+func TestAtomicLevel(t *testing.T) {
+	level := NewAtomicLevel(Warn)
+	if got := level.Level(); got != Warn {
+		t.Errorf("Level() = %v, expected %v", got, Warn)
+	}
+
+	level.SetLevel(Debug)
+	if got := level.Level(); got != Debug {
+		t.Errorf("Level() after SetLevel = %v, expected %v", got, Debug)
+	}
+}
+
+func TestAtomicLevel_Concurrent(t *testing.T) {
+	level := NewAtomicLevel(Info)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			if i%2 == 0 {
+				level.SetLevel(Error)
+			}
+			_ = level.Level()
+		}(i)
+	}
+	wg.Wait()
+
+	if got := level.Level(); got != Error {
+		t.Errorf("Level() = %v, expected %v", got, Error)
+	}
+}
+
+func TestConfig_LevelFor_AtomicLevel(t *testing.T) {
+	config := Config{LogLevel: Warn}
+	config.Default()
+
+	if level := config.LevelFor(""); level != Warn {
+		t.Errorf("Expected Default to initialize Level from LogLevel, got %v", level)
+	}
+
+	config.Level.SetLevel(Error)
+	if level := config.LevelFor(""); level != Error {
+		t.Errorf("Expected LevelFor to follow the atomic level, got %v", level)
+	}
+}
+
+func TestConfig_MinLevel(t *testing.T) {
+	level := NewAtomicLevel(Warn)
+	named := NewNamedLevels(map[string]Level{"db": Error})
+	config := Config{Level: level, NamedLevels: named}
+
+	if got := config.MinLevel(); got != Warn {
+		t.Errorf("MinLevel() = %v, expected %v", got, Warn)
+	}
+	level.SetLevel(Error)
+	if got := config.MinLevel(); got != Error {
+		t.Errorf("MinLevel() after SetLevel = %v, expected %v", got, Error)
+	}
+	named.SetLevel("db.pool", Debug)
+	if got := config.MinLevel(); got != Debug {
+		t.Errorf("MinLevel() after NamedLevels.SetLevel = %v, expected %v", got, Debug)
+	}
+	named.Reset(nil)
+	if got := config.MinLevel(); got != Error {
+		t.Errorf("MinLevel() after NamedLevels.Reset = %v, expected %v", got, Error)
+	}
+}
+
+func TestAtomicAttrs(t *testing.T) {
+	attrs := NewAtomicAttrs(map[string]string{"service": "api", "env": "prod"})
+	expected := []any{"env", "prod", "service", "api"}
+	if got := attrs.KeysAndValues(); !reflect.DeepEqual(got, expected) {
+		t.Errorf("KeysAndValues() = %v, expected %v", got, expected)
+	}
+
+	attrs.Store(nil)
+	if got := attrs.KeysAndValues(); len(got) != 0 {
+		t.Errorf("Expected no attributes after Store(nil), got %v", got)
+	}
+}
+
+func TestAtomicWriter(t *testing.T) {
+	var first, second bytes.Buffer
+	w := NewAtomicWriter(&first)
+
+	fmt.Fprint(w, "one")
+	prev := w.Swap(&second)
+	fmt.Fprint(w, "two")
+
+	if first.String() != "one" || second.String() != "two" {
+		t.Errorf("Unexpected outputs: %q, %q", first.String(), second.String())
+	}
+	if len(prev) != 1 || prev[0] != &first {
+		t.Errorf("Expected Swap to return the previous outputs, got %v", prev)
+	}
+}
@@ -0,0 +1,97 @@
+package log
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// DefaultBackend is the backend used by New if neither its argument nor
+// Config.Backend names one. It is registered by importing github.com/prakashpandey/golog/slog.
+const DefaultBackend = "slog"
+
+// BackendFactory builds a Logger from a configuration. It reports an invalid
+// configuration as an error, e.g. the one returned by Config.Validate.
+type BackendFactory func(config Config) (Logger, error)
+
+// backends holds the registered backend factories by normalized name.
+var backends = struct {
+	sync.RWMutex
+	factories map[string]BackendFactory
+}{factories: make(map[string]BackendFactory)}
+
+// RegisterBackend makes a backend available by name to New. Names are case-insensitive.
+// Backends usually register themselves in an init function, so that importing
+// their package is enough, e.g.
+//
+//	import _ "github.com/prakashpandey/golog/zap"
+//
+// RegisterBackend panics if name is empty, factory is nil or name is already registered.
+func RegisterBackend(name string, factory BackendFactory) {
+	key := normalizeBackend(name)
+	if key == "" {
+		panic("log: RegisterBackend with empty name")
+	}
+	if factory == nil {
+		panic("log: RegisterBackend with nil factory for backend " + name)
+	}
+	backends.Lock()
+	defer backends.Unlock()
+	if _, ok := backends.factories[key]; ok {
+		panic("log: RegisterBackend called twice for backend " + name)
+	}
+	backends.factories[key] = factory
+}
+
+// unregisterBackend removes the backend registered by name, so that tests can
+// register it again.
+func unregisterBackend(name string) {
+	backends.Lock()
+	defer backends.Unlock()
+	delete(backends.factories, normalizeBackend(name))
+}
+
+// Backends returns the sorted names of the registered backends.
+func Backends() []string {
+	backends.RLock()
+	defer backends.RUnlock()
+	names := make([]string, 0, len(backends.factories))
+	for name := range backends.factories {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// New builds a Logger for config with the named backend, which must have been
+// registered with RegisterBackend. An empty backend selects config.Backend,
+// or DefaultBackend if that is empty too.
+func New(backend string, config Config) (Logger, error) {
+	if strings.TrimSpace(backend) == "" {
+		backend = config.Backend
+	}
+	if strings.TrimSpace(backend) == "" {
+		backend = DefaultBackend
+	}
+	key := normalizeBackend(backend)
+	factory, ok := lookupBackend(key)
+	if !ok {
+		return nil, fmt.Errorf("unknown log backend %q, registered: %s", backend, strings.Join(Backends(), ", "))
+	}
+	config.Backend = key
+	return factory(config)
+}
+
+// lookupBackend returns the factory registered for the normalized name key.
+func lookupBackend(key string) (BackendFactory, bool) {
+	backends.RLock()
+	defer backends.RUnlock()
+	factory, ok := backends.factories[key]
+	return factory, ok
+}
+
+// normalizeBackend trims white spaces from a backend name and lowercases it.
+func normalizeBackend(name string) string {
+	return strings.ToLower(strings.TrimSpace(name))
+}
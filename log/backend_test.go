@@ -0,0 +1,82 @@
+package log
+
+import (
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+)
+
+// backendLogger is a Logger built by the test backend, recording its config.
+type backendLogger struct {
+	nopLogger
+	config Config
+}
+
+func TestRegisterBackend(t *testing.T) {
+	RegisterBackend("Test-Backend", func(config Config) (Logger, error) {
+		if config.OutputFormat == "XML" {
+			return nil, errors.New("invalid format")
+		}
+		return backendLogger{config: config}, nil
+	})
+	t.Cleanup(func() { unregisterBackend("Test-Backend") })
+
+	if !slices.Contains(Backends(), "test-backend") {
+		t.Errorf("Expected test-backend to be registered, got %v", Backends())
+	}
+
+	logger, err := New(" TEST-backend ", Config{})
+	if err != nil {
+		t.Fatalf("New returned an unexpected error: %v", err)
+	}
+	if l, ok := logger.(backendLogger); !ok || l.config.Backend != "test-backend" {
+		t.Errorf("Expected a logger of the test backend, got %#v", logger)
+	}
+
+	logger, err = New("", Config{Backend: "test-backend"})
+	if err != nil || logger == nil {
+		t.Errorf("Expected New to select Config.Backend, got %v, %v", logger, err)
+	}
+
+	if _, err := New("test-backend", Config{OutputFormat: "XML"}); err == nil {
+		t.Errorf("Expected the error of the factory")
+	}
+}
+
+func TestRegisterBackend_Invalid(t *testing.T) {
+	factory := func(config Config) (Logger, error) { return Nop(), nil }
+	RegisterBackend("test-duplicate", factory)
+	t.Cleanup(func() { unregisterBackend("test-duplicate") })
+
+	tests := []struct {
+		name    string
+		factory BackendFactory
+	}{
+		{" ", factory},
+		{"test-nil", nil},
+		{"Test-Duplicate", factory},
+	}
+	for _, tt := range tests {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("Expected RegisterBackend(%q) to panic", tt.name)
+				}
+			}()
+			RegisterBackend(tt.name, tt.factory)
+		}()
+	}
+}
+
+func TestNew_UnknownBackend(t *testing.T) {
+	// The default backend is not registered in this package's tests.
+	_, err := New("", Config{})
+	if err == nil || !strings.Contains(err.Error(), DefaultBackend) {
+		t.Errorf("Expected New to select the unregistered default backend, got %v", err)
+	}
+	_, err = New("unknown", Config{})
+	if err == nil || !strings.Contains(err.Error(), `"unknown"`) {
+		t.Errorf("Expected an unknown backend error, got %v", err)
+	}
+}
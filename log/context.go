@@ -0,0 +1,85 @@
+package log
+
+import (
+	"context"
+)
+
+// fieldsKey is the context key for fields stored with ContextWithFields.
+type fieldsKey struct{}
+
+// ContextWithFields returns a copy of ctx that carries keysAndValues in addition
+// to the fields already stored in ctx. Every backend logs the fields stored in
+// the context at the top level of each entry logged with it, outside the groups
+// opened with WithGroup.
+// Malformed elements of keysAndValues are stored as the Fields returned by
+// NextField, so that they cannot shift the fields added later.
+func ContextWithFields(ctx context.Context, keysAndValues ...any) context.Context {
+	if len(keysAndValues) == 0 {
+		return ctx
+	}
+	fields := FieldsFromContext(ctx)
+	merged := make([]any, 0, len(fields)+len(keysAndValues))
+	merged = append(merged, fields...)
+	for i := 0; i < len(keysAndValues); {
+		f, next, ok := NextField(keysAndValues, i)
+		if ok {
+			merged = append(merged, keysAndValues[i:next]...)
+		} else {
+			merged = append(merged, f)
+		}
+		i = next
+	}
+	return context.WithValue(ctx, fieldsKey{}, merged)
+}
+
+// FieldsFromContext returns the keys and values stored in ctx with ContextWithFields.
+// The returned slice must not be modified.
+func FieldsFromContext(ctx context.Context) []any {
+	if ctx == nil {
+		return nil
+	}
+	fields, _ := ctx.Value(fieldsKey{}).([]any)
+	return fields
+}
+
+// loggerKey is the context key for the logger stored with NewContext.
+type loggerKey struct{}
+
+// NewContext returns a copy of ctx that carries logger.
+func NewContext(ctx context.Context, logger Logger) context.Context {
+	return context.WithValue(ctx, loggerKey{}, logger)
+}
+
+// FromContext returns the logger stored in ctx with NewContext.
+// If ctx carries no logger, the default logger returned by Default is returned.
+func FromContext(ctx context.Context) Logger {
+	if ctx != nil {
+		if logger, ok := ctx.Value(loggerKey{}).(Logger); ok && logger != nil {
+			return logger
+		}
+	}
+	return Default()
+}
+
+// callerKey is the context key for the caller stored with ContextWithCaller.
+type callerKey struct{}
+
+// ContextWithCaller returns a copy of ctx that makes backends report pc, a program
+// counter as returned by runtime.Callers, as the caller of entries logged with it
+// instead of the caller of the logging method. It is meant for adapters that know
+// the call site, such as a log/slog Handler. A zero pc returns ctx unchanged.
+func ContextWithCaller(ctx context.Context, pc uintptr) context.Context {
+	if pc == 0 {
+		return ctx
+	}
+	return context.WithValue(ctx, callerKey{}, pc)
+}
+
+// CallerFromContext returns the caller stored in ctx with ContextWithCaller.
+func CallerFromContext(ctx context.Context) (uintptr, bool) {
+	if ctx == nil {
+		return 0, false
+	}
+	pc, ok := ctx.Value(callerKey{}).(uintptr)
+	return pc, ok
+}
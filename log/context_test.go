@@ -0,0 +1,85 @@
+package log
+
+import (
+	"context"
+	"reflect"
+	"testing"
+)
+
+func TestContextWithFields(t *testing.T) {
+	ctx := context.Background()
+	if fields := FieldsFromContext(ctx); fields != nil {
+		t.Errorf("Expected no fields in empty context, got %v", fields)
+	}
+
+	parent := ContextWithFields(ctx, "request_id", "abc123")
+	child := ContextWithFields(parent, "user_id", 42)
+
+	expected := []any{"request_id", "abc123", "user_id", 42}
+	if fields := FieldsFromContext(child); !reflect.DeepEqual(fields, expected) {
+		t.Errorf("FieldsFromContext(child) = %v, expected %v", fields, expected)
+	}
+	expected = []any{"request_id", "abc123"}
+	if fields := FieldsFromContext(parent); !reflect.DeepEqual(fields, expected) {
+		t.Errorf("FieldsFromContext(parent) = %v, expected %v", fields, expected)
+	}
+}
+
+func TestContextWithFields_Malformed(t *testing.T) {
+	parent := ContextWithFields(context.Background(), 42, "user")
+	child := ContextWithFields(parent, "request_id", "abc123")
+
+	expected := []any{Field{Key: BadKey, Value: 42}, String("user", MissingValue), "request_id", "abc123"}
+	if fields := FieldsFromContext(child); !reflect.DeepEqual(fields, expected) {
+		t.Errorf("FieldsFromContext(child) = %v, expected %v", fields, expected)
+	}
+}
+
+func TestContextWithFields_NoFields(t *testing.T) {
+	ctx := context.Background()
+	if got := ContextWithFields(ctx); got != ctx {
+		t.Errorf("Expected ContextWithFields without fields to return ctx unchanged")
+	}
+}
+
+// namedNopLogger is a no-op logger that can be told apart from others by its name.
+type namedNopLogger struct {
+	nopLogger
+	name string
+}
+
+func TestFromContext(t *testing.T) {
+	logger := namedNopLogger{name: "request"}
+	ctx := NewContext(context.Background(), logger)
+
+	if got := FromContext(ctx); got != logger {
+		t.Errorf("FromContext() = %v, expected %v", got, logger)
+	}
+}
+
+func TestFromContext_FallsBackToDefault(t *testing.T) {
+	if got := FromContext(context.Background()); got != Default() {
+		t.Errorf("Expected FromContext to fall back to the built-in default logger, got %v", got)
+	}
+
+	logger := namedNopLogger{name: "default"}
+	SetDefault(logger)
+	defer SetDefault(nil)
+
+	if got := FromContext(context.Background()); got != logger {
+		t.Errorf("Expected FromContext to fall back to the default logger, got %v", got)
+	}
+}
+
+func TestContextWithCaller(t *testing.T) {
+	ctx := context.Background()
+	if got := ContextWithCaller(ctx, 0); got != ctx {
+		t.Errorf("Expected ContextWithCaller with a zero pc to return ctx unchanged")
+	}
+	if _, ok := CallerFromContext(ctx); ok {
+		t.Errorf("Expected no caller in an empty context")
+	}
+	if pc, ok := CallerFromContext(ContextWithCaller(ctx, 42)); !ok || pc != 42 {
+		t.Errorf("CallerFromContext() = %d, %v, expected 42, true", pc, ok)
+	}
+}
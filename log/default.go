@@ -0,0 +1,99 @@
+package log
+
+import (
+	"context"
+	"io"
+	"os"
+	"sync/atomic"
+)
+
+// defaultLoggers holds the default logger and its child used by the package-level functions.
+type defaultLoggers struct {
+	logger Logger
+	skip   Logger // logger.WithCallerSkip(1), so that caller information points at the caller of e.g. InfoContext.
+}
+
+func newDefaultLoggers(logger Logger) *defaultLoggers {
+	return &defaultLoggers{logger: logger, skip: logger.WithCallerSkip(1)}
+}
+
+var (
+	// defaultLogger holds the logger set with SetDefault.
+	defaultLogger atomic.Pointer[defaultLoggers]
+
+	// builtinLogger holds the logger built by Default when none is set.
+	builtinLogger atomic.Pointer[defaultLoggers]
+
+	// fallbackLoggers is used by Default if DefaultBackend is not registered.
+	fallbackLoggers = newDefaultLoggers(newFallbackLogger(os.Stderr))
+)
+
+// SetDefault makes logger the default logger returned by Default and FromContext
+// and used by the package-level logging functions such as InfoContext.
+// A nil logger restores the built-in default logger.
+func SetDefault(logger Logger) {
+	if logger == nil {
+		defaultLogger.Store(nil)
+		return
+	}
+	defaultLogger.Store(newDefaultLoggers(logger))
+}
+
+// Default returns the default logger. Until one is set with SetDefault, it is
+// built from DefaultConfig with os.Stderr as output and DefaultBackend, i.e. it
+// writes TEXT entries at Info level and above to stderr. If DefaultBackend is not
+// registered, because its package is not imported, Default returns a minimal built-in
+// logger that writes the same entries without caller information or stack traces.
+func Default() Logger {
+	return loadDefault().logger
+}
+
+// loadDefault returns the loggers set with SetDefault, building the built-in
+// default loggers if none are set.
+func loadDefault() *defaultLoggers {
+	if d := defaultLogger.Load(); d != nil {
+		return d
+	}
+	if d := builtinLogger.Load(); d != nil {
+		return d
+	}
+	// The backend may still be registered later, so its absence is not cached.
+	if _, ok := lookupBackend(DefaultBackend); !ok {
+		return fallbackLoggers
+	}
+	config := DefaultConfig()
+	config.Outputs = []io.Writer{os.Stderr}
+	logger, err := New(DefaultBackend, config)
+	if err != nil {
+		return fallbackLoggers
+	}
+	builtinLogger.CompareAndSwap(nil, newDefaultLoggers(logger))
+	return builtinLogger.Load()
+}
+
+// Nop returns a logger that discards all entries.
+// Panic still panics and Fatal still calls Exit(nil, 1) so that control flow is the same
+// as with any other logger.
+func Nop() Logger {
+	return nopLogger{}
+}
+
+// nopLogger is a Logger that discards all entries.
+type nopLogger struct{}
+
+func (nopLogger) Trace(ctx context.Context, msg string, keysAndValues ...any)    {}
+func (nopLogger) Debug(ctx context.Context, msg string, keysAndValues ...any)    {}
+func (nopLogger) Info(ctx context.Context, msg string, keysAndValues ...any)     {}
+func (nopLogger) Notice(ctx context.Context, msg string, keysAndValues ...any)   {}
+func (nopLogger) Warn(ctx context.Context, msg string, keysAndValues ...any)     {}
+func (nopLogger) Error(ctx context.Context, msg string, keysAndValues ...any)    {}
+func (nopLogger) Critical(ctx context.Context, msg string, keysAndValues ...any) {}
+func (nopLogger) Panic(ctx context.Context, msg string, keysAndValues ...any)    { panic(msg) }
+func (nopLogger) Fatal(ctx context.Context, msg string, keysAndValues ...any)    { Exit(nil, 1) }
+func (l nopLogger) With(keysAndValues ...any) Logger                             { return l }
+func (l nopLogger) WithGroup(name string) Logger                                 { return l }
+func (l nopLogger) Named(name string) Logger                                     { return l }
+func (nopLogger) Enabled(ctx context.Context, level Level) bool                  { return false }
+func (l nopLogger) WithCallerSkip(skip int) Logger                               { return l }
+func (nopLogger) Sync() error                                                    { return nil }
+func (nopLogger) Close() error                                                   { return nil }
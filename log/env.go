@@ -0,0 +1,180 @@
+package log
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// Names of the environment variables read by ConfigFromEnv, without prefix.
+const (
+	EnvLevel           = "LEVEL"            // Minimum log level, e.g. "debug".
+	EnvFormat          = "FORMAT"           // Output format, "text" or "json".
+	EnvOutputs         = "OUTPUTS"          // Comma separated outputs: "stdout", "stderr" or file paths.
+	EnvCaller          = "CALLER"           // Whether caller information is logged, e.g. "true".
+	EnvStacktraceLevel = "STACKTRACE_LEVEL" // Minimum log level to record stack traces.
+	EnvAttrs           = "ATTRS"            // Comma separated attributes, e.g. "k=v,k2=v2".
+	EnvBackend         = "BACKEND"          // Name of the backend used by New, e.g. "zap".
+	EnvStrict          = "STRICT"           // Whether malformed keysAndValues are reported, e.g. "true" in CI.
+)
+
+// ConfigFromEnv returns DefaultConfig() overridden by the environment variables
+// named prefix + "_" + Env*, e.g. GOLOG_LEVEL for the prefix "GOLOG".
+// Unset or empty variables keep their default. All invalid values are reported
+// in the returned error.
+func ConfigFromEnv(prefix string) (Config, error) {
+	config := DefaultConfig()
+	var errs []error
+
+	lookup := func(name string) (string, string, bool) {
+		if prefix != "" {
+			name = prefix + "_" + name
+		}
+		value, ok := os.LookupEnv(name)
+		value = strings.TrimSpace(value)
+		return name, value, ok && value != ""
+	}
+
+	if name, value, ok := lookup(EnvLevel); ok {
+		level, err := ParseLevel(value)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", name, err))
+		}
+		config.LogLevel = level
+	}
+	if name, value, ok := lookup(EnvFormat); ok {
+		format, err := ParseOutputFormat(value)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", name, err))
+		}
+		config.OutputFormat = format
+	}
+	if name, value, ok := lookup(EnvCaller); ok {
+		enabled, err := strconv.ParseBool(value)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: invalid boolean: %s", name, value))
+		}
+		config.Caller.Enabled = enabled
+	}
+	if name, value, ok := lookup(EnvStacktraceLevel); ok {
+		level, err := ParseLevel(value)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", name, err))
+		}
+		config.Stacktrace.Level = level
+	}
+	if name, value, ok := lookup(EnvAttrs); ok {
+		attrs, err := parseAttrs(value)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", name, err))
+		}
+		config.Attrs = attrs
+	}
+	if _, value, ok := lookup(EnvBackend); ok {
+		config.Backend = value
+	}
+	if name, value, ok := lookup(EnvStrict); ok {
+		strict, err := strconv.ParseBool(value)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: invalid boolean: %s", name, value))
+		}
+		config.Strict = strict
+	}
+	// Outputs are opened last so that no files are opened for an invalid configuration.
+	if name, value, ok := lookup(EnvOutputs); ok && len(errs) == 0 {
+		outputs, err := openOutputs(strings.Split(value, ","))
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", name, err))
+		}
+		config.Outputs = outputs
+	}
+
+	if err := errors.Join(errs...); err != nil {
+		return Config{}, err
+	}
+	return config, nil
+}
+
+// ParseOutputFormat parses an output format name case-insensitively, e.g. "json".
+func ParseOutputFormat(formatStr string) (OutputFormat, error) {
+	s := strings.TrimSpace(formatStr)
+	for _, format := range []OutputFormat{OutputFormatTEXT, OutputFormatJSON} {
+		if strings.EqualFold(s, string(format)) {
+			return format, nil
+		}
+	}
+	return "", errors.New("invalid output format: " + formatStr)
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler using ParseOutputFormat.
+func (f *OutputFormat) UnmarshalText(text []byte) error {
+	format, err := ParseOutputFormat(string(text))
+	if err != nil {
+		return err
+	}
+	*f = format
+	return nil
+}
+
+// parseAttrs parses comma separated key=value pairs.
+func parseAttrs(s string) (map[string]string, error) {
+	attrs := make(map[string]string)
+	for _, pair := range strings.Split(s, ",") {
+		if strings.TrimSpace(pair) == "" {
+			continue
+		}
+		key, value, ok := strings.Cut(pair, "=")
+		key = strings.TrimSpace(key)
+		if !ok || key == "" {
+			return nil, fmt.Errorf("invalid attribute %q: expected key=value", pair)
+		}
+		attrs[key] = strings.TrimSpace(value)
+	}
+	return attrs, nil
+}
+
+// openOutputs opens the named outputs with openOutput.
+// If one cannot be opened, the files opened so far are closed.
+func openOutputs(names []string) ([]io.Writer, error) {
+	var outputs []io.Writer
+	for _, name := range names {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+		output, err := openOutput(name)
+		if err != nil {
+			closeOutputs(outputs)
+			return nil, err
+		}
+		outputs = append(outputs, output)
+	}
+	if len(outputs) == 0 {
+		return nil, errors.New("no outputs")
+	}
+	return outputs, nil
+}
+
+// openOutput returns os.Stdout for "stdout", os.Stderr for "stderr" and
+// otherwise opens the file at path name for appending, creating it if needed.
+func openOutput(name string) (io.Writer, error) {
+	switch strings.ToLower(name) {
+	case "stdout":
+		return os.Stdout, nil
+	case "stderr":
+		return os.Stderr, nil
+	}
+	f, err := os.OpenFile(name, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0o644)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open output: %w", err)
+	}
+	return f, nil
+}
+
+// closeOutputs closes the outputs opened by openOutput, except for os.Stdout and os.Stderr.
+func closeOutputs(outputs []io.Writer) {
+	_ = CloseOutputs(outputs)
+}
@@ -0,0 +1,121 @@
+package log
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestConfigFromEnv_Defaults(t *testing.T) {
+	config, err := ConfigFromEnv("GOLOG_TEST")
+	if err != nil {
+		t.Fatalf("ConfigFromEnv returned an unexpected error: %v", err)
+	}
+
+	expected := DefaultConfig()
+	if config.LogLevel != expected.LogLevel || config.OutputFormat != expected.OutputFormat ||
+		config.Caller != expected.Caller || config.Stacktrace != expected.Stacktrace ||
+		len(config.Outputs) != 1 || config.Outputs[0] != os.Stdout {
+		t.Errorf("Expected the default config, got %+v", config)
+	}
+}
+
+func TestConfigFromEnv(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "app.log")
+	t.Setenv("GOLOG_LEVEL", "Warn")
+	t.Setenv("GOLOG_FORMAT", "json")
+	t.Setenv("GOLOG_OUTPUTS", "stderr, "+path)
+	t.Setenv("GOLOG_CALLER", "false")
+	t.Setenv("GOLOG_STACKTRACE_LEVEL", "critical")
+	t.Setenv("GOLOG_ATTRS", "service=api, env=prod")
+	t.Setenv("GOLOG_BACKEND", "zap")
+	t.Setenv("GOLOG_STRICT", "true")
+
+	config, err := ConfigFromEnv("GOLOG")
+	if err != nil {
+		t.Fatalf("ConfigFromEnv returned an unexpected error: %v", err)
+	}
+	defer closeOutputs(config.Outputs)
+
+	if config.LogLevel != Warn {
+		t.Errorf("Expected level warn, got %v", config.LogLevel)
+	}
+	if config.OutputFormat != OutputFormatJSON {
+		t.Errorf("Expected format JSON, got %v", config.OutputFormat)
+	}
+	if len(config.Outputs) != 2 || config.Outputs[0] != os.Stderr {
+		t.Errorf("Expected stderr and a file as outputs, got %v", config.Outputs)
+	}
+	if f, ok := config.Outputs[1].(*os.File); !ok || f.Name() != path {
+		t.Errorf("Expected file %s as second output, got %v", path, config.Outputs[1])
+	}
+	if config.Caller.Enabled {
+		t.Errorf("Expected caller to be disabled")
+	}
+	if config.Stacktrace.Level != Critical {
+		t.Errorf("Expected stack trace level critical, got %v", config.Stacktrace.Level)
+	}
+	if len(config.Attrs) != 2 || config.Attrs["service"] != "api" || config.Attrs["env"] != "prod" {
+		t.Errorf("Unexpected attrs: %v", config.Attrs)
+	}
+	if config.Backend != "zap" {
+		t.Errorf("Expected backend zap, got %q", config.Backend)
+	}
+	if !config.Strict {
+		t.Errorf("Expected strict mode")
+	}
+}
+
+func TestConfigFromEnv_InvalidValues(t *testing.T) {
+	t.Setenv("GOLOG_LEVEL", "verbose")
+	t.Setenv("GOLOG_FORMAT", "xml")
+	t.Setenv("GOLOG_CALLER", "maybe")
+	t.Setenv("GOLOG_STACKTRACE_LEVEL", "sometimes")
+	t.Setenv("GOLOG_ATTRS", "service")
+	t.Setenv("GOLOG_STRICT", "always")
+
+	_, err := ConfigFromEnv("GOLOG")
+	if err == nil {
+		t.Fatal("Expected an error for invalid values")
+	}
+	for _, name := range []string{"GOLOG_LEVEL", "GOLOG_FORMAT", "GOLOG_CALLER", "GOLOG_STACKTRACE_LEVEL", "GOLOG_ATTRS", "GOLOG_STRICT"} {
+		if !strings.Contains(err.Error(), name) {
+			t.Errorf("Expected error to mention %s, got: %v", name, err)
+		}
+	}
+}
+
+func TestConfigFromEnv_InvalidOutput(t *testing.T) {
+	t.Setenv("GOLOG_OUTPUTS", filepath.Join(t.TempDir(), "missing", "app.log"))
+
+	if _, err := ConfigFromEnv("GOLOG"); err == nil || !strings.Contains(err.Error(), "GOLOG_OUTPUTS") {
+		t.Errorf("Expected an error for GOLOG_OUTPUTS, got: %v", err)
+	}
+}
+
+func TestParseOutputFormat(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected OutputFormat
+		err      bool
+	}{
+		{"TEXT", OutputFormatTEXT, false},
+		{"json", OutputFormatJSON, false},
+		{" Json ", OutputFormatJSON, false},
+		{"xml", "", true},
+		{"", "", true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.input, func(t *testing.T) {
+			format, err := ParseOutputFormat(test.input)
+			if (err != nil) != test.err {
+				t.Errorf("ParseOutputFormat(%q) error = %v, expected error = %v", test.input, err, test.err)
+			}
+			if format != test.expected {
+				t.Errorf("ParseOutputFormat(%q) = %q, expected %q", test.input, format, test.expected)
+			}
+		})
+	}
+}
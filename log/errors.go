@@ -0,0 +1,93 @@
+package log
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// ErrorKey is the key of the Field returned by Err.
+const ErrorKey = "error"
+
+// nilErrorMsg is logged for errors that hold a nil pointer, like zap does.
+const nilErrorMsg = "<nil>"
+
+// maxErrorChain bounds the length of ErrorDetails.Chain, e.g. for cyclic chains.
+const maxErrorChain = 32
+
+// Err returns a Field with ErrorKey and err.
+func Err(err error) Field {
+	return Field{Key: ErrorKey, Value: err}
+}
+
+// ErrorDetails is the structured form in which the backends log error values, e.g.
+//
+//	{"msg":"load config: open app.json: no such file","type":"*fmt.wrapError",
+//	 "chain":[{"msg":"open app.json: no such file","type":"*fs.PathError"}, ...]}
+type ErrorDetails struct {
+	Msg    string       `json:"msg"`
+	Type   string       `json:"type"`
+	Chain  []ErrorCause `json:"chain,omitempty"`  // Wrapped errors in depth-first order, following errors.Unwrap and errors.Join.
+	Detail string       `json:"detail,omitempty"` // The "%+v" form of an error implementing fmt.Formatter, e.g. with a stack trace, if it differs from Msg.
+}
+
+// ErrorCause is a wrapped error in ErrorDetails.Chain.
+type ErrorCause struct {
+	Msg  string `json:"msg"`
+	Type string `json:"type"`
+}
+
+// IsNilError reports whether err is nil or holds a nil pointer, e.g. a nil *MyError
+// assigned to an error. Calling the Error method of such an error may panic, so
+// backends log it as "<nil>", like zap does.
+func IsNilError(err error) bool {
+	if err == nil {
+		return true
+	}
+	switch v := reflect.ValueOf(err); v.Kind() {
+	case reflect.Pointer, reflect.Map, reflect.Slice, reflect.Func, reflect.Chan, reflect.Interface, reflect.UnsafePointer:
+		return v.IsNil()
+	}
+	return false
+}
+
+// NewErrorDetails returns the details of err, which must not be nil. Msg is "<nil>"
+// if err holds a nil pointer, see IsNilError.
+func NewErrorDetails(err error) ErrorDetails {
+	if IsNilError(err) {
+		return ErrorDetails{Msg: nilErrorMsg, Type: fmt.Sprintf("%T", err)}
+	}
+	d := ErrorDetails{
+		Msg:  err.Error(),
+		Type: fmt.Sprintf("%T", err),
+	}
+	d.Chain = appendCauses(d.Chain, err)
+	if _, ok := err.(fmt.Formatter); ok {
+		if detail := fmt.Sprintf("%+v", err); detail != d.Msg {
+			d.Detail = detail
+		}
+	}
+	return d
+}
+
+// appendCauses appends the errors wrapped by err to chain in depth-first order.
+func appendCauses(chain []ErrorCause, err error) []ErrorCause {
+	var wrapped []error
+	switch e := err.(type) {
+	case interface{ Unwrap() error }:
+		wrapped = []error{e.Unwrap()}
+	case interface{ Unwrap() []error }:
+		wrapped = e.Unwrap()
+	}
+	for _, w := range wrapped {
+		if w == nil || len(chain) >= maxErrorChain {
+			continue
+		}
+		if IsNilError(w) {
+			chain = append(chain, ErrorCause{Msg: nilErrorMsg, Type: fmt.Sprintf("%T", w)})
+			continue
+		}
+		chain = append(chain, ErrorCause{Msg: w.Error(), Type: fmt.Sprintf("%T", w)})
+		chain = appendCauses(chain, w)
+	}
+	return chain
+}
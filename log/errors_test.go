@@ -0,0 +1,88 @@
+package log
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"testing"
+)
+
+// detailedError implements fmt.Formatter with more detail for "%+v".
+type detailedError struct{}
+
+func (detailedError) Error() string { return "detailed" }
+
+func (e detailedError) Format(s fmt.State, verb rune) {
+	if s.Flag('+') {
+		fmt.Fprint(s, "detailed\nwith stack")
+		return
+	}
+	fmt.Fprint(s, e.Error())
+}
+
+// ptrError has an Error method that panics for a nil receiver.
+type ptrError struct{ msg string }
+
+func (e *ptrError) Error() string { return e.msg }
+
+func TestErr(t *testing.T) {
+	err := errors.New("boom")
+	if f := Err(err); f.Key != ErrorKey || f.Value != err {
+		t.Errorf("Unexpected field: %+v", f)
+	}
+}
+
+func TestNewErrorDetails(t *testing.T) {
+	inner := fmt.Errorf("read config: %w", io.EOF)
+	err := fmt.Errorf("start: %w", errors.Join(inner, detailedError{}))
+
+	d := NewErrorDetails(err)
+	if d.Msg != err.Error() || d.Type != "*fmt.wrapError" || d.Detail != "" {
+		t.Errorf("Unexpected details: %+v", d)
+	}
+	expected := []ErrorCause{
+		{Msg: "read config: EOF\ndetailed", Type: "*errors.joinError"},
+		{Msg: "read config: EOF", Type: "*fmt.wrapError"},
+		{Msg: "EOF", Type: "*errors.errorString"},
+		{Msg: "detailed", Type: "log.detailedError"},
+	}
+	if !reflect.DeepEqual(d.Chain, expected) {
+		t.Errorf("Unexpected chain: %+v", d.Chain)
+	}
+
+	if d := NewErrorDetails(detailedError{}); d.Detail != "detailed\nwith stack" || d.Chain != nil {
+		t.Errorf("Unexpected details: %+v", d)
+	}
+}
+
+func TestIsNilError(t *testing.T) {
+	var typedNil *ptrError
+	tests := []struct {
+		err      error
+		expected bool
+	}{
+		{nil, true},
+		{typedNil, true},
+		{&ptrError{"boom"}, false},
+		{io.EOF, false},
+		{detailedError{}, false},
+	}
+	for _, tt := range tests {
+		if got := IsNilError(tt.err); got != tt.expected {
+			t.Errorf("IsNilError(%#v) = %v, expected %v", tt.err, got, tt.expected)
+		}
+	}
+}
+
+func TestNewErrorDetails_TypedNil(t *testing.T) {
+	var typedNil *ptrError
+
+	if d := NewErrorDetails(typedNil); d.Msg != "<nil>" || d.Type != "*log.ptrError" || d.Chain != nil {
+		t.Errorf("Unexpected details: %+v", d)
+	}
+	d := NewErrorDetails(fmt.Errorf("wrap: %w", typedNil))
+	if expected := []ErrorCause{{Msg: "<nil>", Type: "*log.ptrError"}}; !reflect.DeepEqual(d.Chain, expected) {
+		t.Errorf("Unexpected chain: %+v", d.Chain)
+	}
+}
@@ -0,0 +1,110 @@
+package log
+
+import (
+	"errors"
+	"os"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// DefaultFatalTimeout is the default time Exit waits for the hooks registered with OnFatal.
+const DefaultFatalTimeout = 5 * time.Second
+
+// ErrFatal is the value PanicOnFatal panics with, so that tests can recover it.
+var ErrFatal = errors.New("log: fatal")
+
+// PanicOnFatal is a Config.ExitFunc for tests. It panics with ErrFatal instead of
+// exiting, so that Fatal paths can be tested by recovering the panic.
+func PanicOnFatal(code int) {
+	panic(ErrFatal)
+}
+
+var (
+	fatalMu      sync.Mutex
+	fatalHooks   []*func() // Pointers so that the function returned by OnFatal removes its own hook.
+	fatalRunning bool      // Whether hooks are running, so that Fatal within a hook does not run them again.
+	fatalTimeout atomic.Int64
+)
+
+func init() {
+	fatalTimeout.Store(int64(DefaultFatalTimeout))
+}
+
+// OnFatal registers hook to run before Fatal exits, e.g. to flush metrics or to
+// shut down servers. Hooks run in the order they were registered. It returns a
+// function that removes the hook.
+func OnFatal(hook func()) (remove func()) {
+	p := &hook
+	fatalMu.Lock()
+	defer fatalMu.Unlock()
+	fatalHooks = append(fatalHooks, p)
+	return func() {
+		fatalMu.Lock()
+		defer fatalMu.Unlock()
+		for i, h := range fatalHooks {
+			if h == p {
+				fatalHooks = append(fatalHooks[:i:i], fatalHooks[i+1:]...)
+				return
+			}
+		}
+	}
+}
+
+// SetFatalTimeout sets the time Exit waits for the hooks registered with OnFatal.
+// A non-positive timeout restores DefaultFatalTimeout.
+func SetFatalTimeout(timeout time.Duration) {
+	if timeout <= 0 {
+		timeout = DefaultFatalTimeout
+	}
+	fatalTimeout.Store(int64(timeout))
+}
+
+// Exit runs the hooks registered with OnFatal and then calls exit with code, or
+// os.Exit if exit is nil. It waits for the hooks at most for the timeout set with
+// SetFatalTimeout. A panic in a hook is recovered and the remaining hooks still run.
+// Backends call it from Fatal with Config.ExitFunc.
+func Exit(exit func(code int), code int) {
+	if exit == nil {
+		exit = os.Exit
+	}
+	runFatalHooks()
+	exit(code)
+}
+
+// runFatalHooks runs the hooks registered with OnFatal unless they are already running.
+func runFatalHooks() {
+	fatalMu.Lock()
+	if fatalRunning {
+		fatalMu.Unlock()
+		return
+	}
+	fatalRunning = true
+	hooks := make([]*func(), len(fatalHooks))
+	copy(hooks, fatalHooks)
+	fatalMu.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for _, hook := range hooks {
+			runFatalHook(*hook)
+		}
+	}()
+	timer := time.NewTimer(time.Duration(fatalTimeout.Load()))
+	defer timer.Stop()
+	select {
+	case <-done:
+	case <-timer.C:
+	}
+
+	fatalMu.Lock()
+	fatalRunning = false
+	fatalMu.Unlock()
+}
+
+// runFatalHook runs hook, recovering a panic.
+func runFatalHook(hook func()) {
+	defer func() { _ = recover() }()
+	hook()
+}
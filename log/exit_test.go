@@ -0,0 +1,61 @@
+package log
+
+import (
+	"testing"
+	"time"
+)
+
+func TestExit_RunsHooks(t *testing.T) {
+	var calls []string
+	removeFirst := OnFatal(func() { calls = append(calls, "first") })
+	defer removeFirst()
+	removePanicking := OnFatal(func() { panic("hook failed") })
+	defer removePanicking()
+	removeSecond := OnFatal(func() { calls = append(calls, "second") })
+	removeSecond()
+	removeThird := OnFatal(func() {
+		// Fatal within a hook does not run the hooks again.
+		Exit(func(int) { calls = append(calls, "nested") }, 1)
+		calls = append(calls, "third")
+	})
+	defer removeThird()
+
+	code := -1
+	Exit(func(c int) { code = c }, 2)
+
+	if code != 2 {
+		t.Errorf("Expected exit code 2, got %d", code)
+	}
+	if len(calls) != 3 || calls[0] != "first" || calls[1] != "nested" || calls[2] != "third" {
+		t.Errorf("Unexpected hook calls: %v", calls)
+	}
+}
+
+func TestExit_Timeout(t *testing.T) {
+	SetFatalTimeout(10 * time.Millisecond)
+	defer SetFatalTimeout(0)
+	block := make(chan struct{})
+	defer close(block)
+	remove := OnFatal(func() { <-block })
+	defer remove()
+
+	start := time.Now()
+	exited := false
+	Exit(func(int) { exited = true }, 1)
+
+	if !exited {
+		t.Errorf("Expected exit to be called after the timeout")
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("Expected Exit to stop waiting for hooks after the timeout, waited %v", elapsed)
+	}
+}
+
+func TestPanicOnFatal(t *testing.T) {
+	defer func() {
+		if r := recover(); r != ErrFatal {
+			t.Errorf("Expected panic with ErrFatal, got: %v", r)
+		}
+	}()
+	Exit(PanicOnFatal, 1)
+}
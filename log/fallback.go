@@ -0,0 +1,198 @@
+package log
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"strings"
+)
+
+// fallbackLogger is the default logger if DefaultBackend is not registered, because
+// its package is not imported. It writes TEXT entries at Info level and above with a
+// slog.TextHandler, without caller information or stack traces, so that the
+// package-level functions never discard errors.
+type fallbackLogger struct {
+	logger *slog.Logger // Logger with the fields added with With.
+	name   string       // Dot-separated logger name, empty for the root logger.
+	prefix string       // Group prefix for keys, e.g. "http.", which yields the dotted keys of TEXT output.
+	skip   int          // Additional stack frames to skip, see WithCallerSkip.
+	config Config       // DefaultConfig with defaults applied, like the built-in default logger.
+}
+
+func newFallbackLogger(w io.Writer) *fallbackLogger {
+	handler := slog.NewTextHandler(w, &slog.HandlerOptions{
+		Level:       slog.Level(Trace), // Levels are enforced by fallbackLogger.
+		ReplaceAttr: replaceFallbackLevel,
+	})
+	config := DefaultConfig()
+	config.Outputs = []io.Writer{w}
+	config.Default()
+	return &fallbackLogger{logger: slog.New(handler), config: config}
+}
+
+// replaceFallbackLevel names the levels of entries, which fallbackLogger logs with
+// their Level value as slog.Level, e.g. "ERROR".
+func replaceFallbackLevel(groups []string, a slog.Attr) slog.Attr {
+	if len(groups) == 0 && a.Key == slog.LevelKey {
+		if level, ok := a.Value.Any().(slog.Level); ok {
+			a.Value = slog.StringValue(strings.ToUpper(Level(level).String()))
+		}
+	}
+	return a
+}
+
+// log writes an entry at level. The logger name and the fields stored in ctx with
+// ContextWithFields are logged at the top level, like the backends do.
+func (l *fallbackLogger) log(ctx context.Context, level Level, msg string, keysAndValues []any) {
+	if !l.Enabled(ctx, level) {
+		return
+	}
+	attrs := make([]slog.Attr, 0, 1+len(keysAndValues))
+	if l.name != "" {
+		attrs = append(attrs, slog.String(l.config.NameField, l.name))
+	}
+	attrs, _ = appendFallbackAttrs(attrs, "", FieldsFromContext(ctx))
+	attrs, bad := appendFallbackAttrs(attrs, l.prefix, keysAndValues)
+	if bad >= 0 {
+		ReportMalformed(ctx, l.config, l.skip+2, keysAndValues[bad]) // log, e.g. Info
+	}
+	l.logger.LogAttrs(ctx, slog.Level(level), msg, attrs...)
+}
+
+// appendFallbackAttrs appends keysAndValues converted to slog.Attrs with keys
+// prefixed by prefix to attrs, see NextField. Errors are logged as ErrorDetails
+// groups. It returns the index of the first malformed element of keysAndValues, or -1.
+func appendFallbackAttrs(attrs []slog.Attr, prefix string, keysAndValues []any) ([]slog.Attr, int) {
+	bad := -1
+	for i := 0; i < len(keysAndValues); {
+		f, next, ok := NextField(keysAndValues, i)
+		if !ok && bad < 0 {
+			bad = i
+		}
+		attrs = append(attrs, fallbackFieldAttr(prefix, f))
+		i = next
+	}
+	return attrs, bad
+}
+
+// fallbackFieldAttr converts f to a slog.Attr with its key prefixed by prefix.
+func fallbackFieldAttr(prefix string, f Field) slog.Attr {
+	if f.Type != GroupType {
+		return fallbackAttr(prefix+f.Key, f.Any())
+	}
+	group := f.Group()
+	attrs := make([]slog.Attr, 0, len(group))
+	for _, gf := range group {
+		attrs = append(attrs, fallbackFieldAttr("", gf))
+	}
+	return slog.Attr{Key: prefix + f.Key, Value: slog.GroupValue(attrs...)}
+}
+
+// fallbackAttr converts a key and a value to a slog.Attr, logging errors as
+// ErrorDetails groups.
+func fallbackAttr(key string, value any) slog.Attr {
+	err, ok := value.(error)
+	if !ok || err == nil {
+		return slog.Any(key, value)
+	}
+	if IsNilError(err) {
+		return slog.String(key, nilErrorMsg)
+	}
+	d := NewErrorDetails(err)
+	attrs := []slog.Attr{slog.String("msg", d.Msg), slog.String("type", d.Type)}
+	if len(d.Chain) > 0 {
+		attrs = append(attrs, slog.Any("chain", d.Chain))
+	}
+	if d.Detail != "" {
+		attrs = append(attrs, slog.String("detail", d.Detail))
+	}
+	return slog.Attr{Key: key, Value: slog.GroupValue(attrs...)}
+}
+
+func (l *fallbackLogger) Trace(ctx context.Context, msg string, keysAndValues ...any) {
+	l.log(ctx, Trace, msg, keysAndValues)
+}
+
+func (l *fallbackLogger) Debug(ctx context.Context, msg string, keysAndValues ...any) {
+	l.log(ctx, Debug, msg, keysAndValues)
+}
+
+func (l *fallbackLogger) Info(ctx context.Context, msg string, keysAndValues ...any) {
+	l.log(ctx, Info, msg, keysAndValues)
+}
+
+func (l *fallbackLogger) Notice(ctx context.Context, msg string, keysAndValues ...any) {
+	l.log(ctx, Notice, msg, keysAndValues)
+}
+
+func (l *fallbackLogger) Warn(ctx context.Context, msg string, keysAndValues ...any) {
+	l.log(ctx, Warn, msg, keysAndValues)
+}
+
+func (l *fallbackLogger) Error(ctx context.Context, msg string, keysAndValues ...any) {
+	l.log(ctx, Error, msg, keysAndValues)
+}
+
+func (l *fallbackLogger) Critical(ctx context.Context, msg string, keysAndValues ...any) {
+	l.log(ctx, Critical, msg, keysAndValues)
+}
+
+func (l *fallbackLogger) Panic(ctx context.Context, msg string, keysAndValues ...any) {
+	l.log(ctx, Panic, msg, keysAndValues)
+	panic(msg)
+}
+
+func (l *fallbackLogger) Fatal(ctx context.Context, msg string, keysAndValues ...any) {
+	l.log(ctx, Fatal, msg, keysAndValues)
+	_ = l.Sync()
+	Exit(l.config.ExitFunc, 1)
+}
+
+func (l *fallbackLogger) With(keysAndValues ...any) Logger {
+	attrs, bad := appendFallbackAttrs(nil, l.prefix, keysAndValues)
+	if bad >= 0 {
+		ReportMalformed(context.Background(), l.config, 1, keysAndValues[bad])
+	}
+	child := *l
+	child.logger = slog.New(l.logger.Handler().WithAttrs(attrs))
+	return &child
+}
+
+func (l *fallbackLogger) WithGroup(name string) Logger {
+	if name == "" {
+		return l
+	}
+	child := *l
+	child.prefix = l.prefix + name + "."
+	return &child
+}
+
+func (l *fallbackLogger) Named(name string) Logger {
+	if name == "" {
+		return l
+	}
+	child := *l
+	child.name = JoinName(l.name, name)
+	return &child
+}
+
+func (l *fallbackLogger) Enabled(ctx context.Context, level Level) bool {
+	return level >= l.config.LevelFor(l.name) && level < Off
+}
+
+// WithCallerSkip returns a child logger that skips skip additional stack frames when
+// reporting malformed keysAndValues, since it logs no caller information.
+func (l *fallbackLogger) WithCallerSkip(skip int) Logger {
+	child := *l
+	child.skip += skip
+	return &child
+}
+
+func (l *fallbackLogger) Sync() error {
+	return SyncOutputs(l.config.Outputs)
+}
+
+// Close flushes the output without closing it, since it is os.Stderr.
+func (l *fallbackLogger) Close() error {
+	return l.Sync()
+}
@@ -0,0 +1,179 @@
+package log
+
+import (
+	"context"
+	"math"
+	"time"
+)
+
+// FieldType tells how a Field stores its value.
+type FieldType uint8
+
+const (
+	AnyType      FieldType = iota // Value holds the value. The type of Field literals.
+	StringType                    // Str holds the value.
+	Int64Type                     // Int holds the value.
+	Float64Type                   // Int holds the IEEE 754 bits of the value.
+	BoolType                      // Int is 1 for true and 0 for false.
+	DurationType                  // Int holds the value in nanoseconds.
+	TimeType                      // Int holds the Unix time in nanoseconds and Value the *time.Location.
+	BytesType                     // Value holds the []byte value.
+	ObjectType                    // Value holds a value to be logged as a nested object.
+	GroupType                     // Value holds the []Field of a group.
+)
+
+// Field is a key and value that can be passed as a single element of keysAndValues
+// instead of a key followed by a value, e.g.
+//
+//	logger.Error(ctx, "request failed", log.Err(err), log.Int64("status", 502))
+//
+// A Field in the position of a value is logged as a value.
+//
+// The typed constructors, e.g. Int64, store values without converting them to an
+// interface, so that backends encode them without reflection. Passing a Field as an
+// element of keysAndValues converts the Field itself to an interface, which
+// allocates, so use FieldLogger.LogFields on hot paths.
+type Field struct {
+	Key   string
+	Value any
+	Type  FieldType
+	Int   int64
+	Str   string
+}
+
+// FieldLogger is a Logger that logs Fields without converting them to interfaces.
+// Both backends implement it, e.g.
+//
+//	if fl, ok := logger.(log.FieldLogger); ok {
+//		fl.LogFields(ctx, log.Info, "request served", log.Int("status", 200))
+//	}
+//
+// Called on a concrete backend type, e.g. *zap.ZapLogger, LogFields does not
+// allocate. Called through the interface, the compiler allocates the fields slice,
+// but not each Field.
+type FieldLogger interface {
+	Logger
+
+	// LogFields logs msg and fields at level like the method for level, e.g. Info.
+	// It panics and exits for Panic and Fatal like Panic and Fatal do.
+	LogFields(ctx context.Context, level Level, msg string, fields ...Field)
+}
+
+// String returns a Field with a string value.
+func String(key, value string) Field {
+	return Field{Key: key, Type: StringType, Str: value}
+}
+
+// Int returns a Field with an int value.
+func Int(key string, value int) Field {
+	return Int64(key, int64(value))
+}
+
+// Int64 returns a Field with an int64 value.
+func Int64(key string, value int64) Field {
+	return Field{Key: key, Type: Int64Type, Int: value}
+}
+
+// Float64 returns a Field with a float64 value.
+func Float64(key string, value float64) Field {
+	return Field{Key: key, Type: Float64Type, Int: int64(math.Float64bits(value))}
+}
+
+// Bool returns a Field with a bool value.
+func Bool(key string, value bool) Field {
+	f := Field{Key: key, Type: BoolType}
+	if value {
+		f.Int = 1
+	}
+	return f
+}
+
+// Duration returns a Field with a time.Duration value.
+func Duration(key string, value time.Duration) Field {
+	return Field{Key: key, Type: DurationType, Int: int64(value)}
+}
+
+// Earliest and latest times whose Unix time in nanoseconds fits in an int64.
+var (
+	minUnixNanoTime = time.Unix(0, math.MinInt64)
+	maxUnixNanoTime = time.Unix(0, math.MaxInt64)
+)
+
+// Time returns a Field with a time.Time value. Times before 1678 or after 2262
+// are stored in Value.
+func Time(key string, value time.Time) Field {
+	if value.Before(minUnixNanoTime) || value.After(maxUnixNanoTime) {
+		return Field{Key: key, Value: value}
+	}
+	return Field{Key: key, Type: TimeType, Int: value.UnixNano(), Value: value.Location()}
+}
+
+// Bytes returns a Field with binary data, which backends log base64 encoded.
+func Bytes(key string, value []byte) Field {
+	return Field{Key: key, Type: BytesType, Value: value}
+}
+
+// Object returns a Field with a value that backends log as a nested object, e.g. a
+// struct or a map. Backends use their own interfaces for custom encodings, e.g.
+// zapcore.ObjectMarshaler and slog.LogValuer.
+func Object(key string, value any) Field {
+	return Field{Key: key, Type: ObjectType, Value: value}
+}
+
+// Group returns a Field that nests fields under key, which backends log like the
+// fields of a logger returned by WithGroup: as a nested object in JSON format and
+// with dotted keys, e.g. "http.method", in the TEXT format of the slog backend.
+// Backends omit groups without fields.
+func Group(key string, fields ...Field) Field {
+	return Field{Key: key, Type: GroupType, Value: fields}
+}
+
+// Group returns the fields of a GroupType Field.
+func (f Field) Group() []Field {
+	fields, _ := f.Value.([]Field)
+	return fields
+}
+
+// Bool returns the value of a BoolType Field.
+func (f Field) Bool() bool {
+	return f.Int == 1
+}
+
+// Float64 returns the value of a Float64Type Field.
+func (f Field) Float64() float64 {
+	return math.Float64frombits(uint64(f.Int))
+}
+
+// Duration returns the value of a DurationType Field.
+func (f Field) Duration() time.Duration {
+	return time.Duration(f.Int)
+}
+
+// Time returns the value of a TimeType Field.
+func (f Field) Time() time.Time {
+	t := time.Unix(0, f.Int)
+	if loc, ok := f.Value.(*time.Location); ok {
+		t = t.In(loc)
+	}
+	return t
+}
+
+// Any returns the value of the Field as an interface.
+func (f Field) Any() any {
+	switch f.Type {
+	case StringType:
+		return f.Str
+	case Int64Type:
+		return f.Int
+	case Float64Type:
+		return f.Float64()
+	case BoolType:
+		return f.Bool()
+	case DurationType:
+		return f.Duration()
+	case TimeType:
+		return f.Time()
+	default:
+		return f.Value
+	}
+}
@@ -0,0 +1,56 @@
+package log
+
+import (
+	"math"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestTypedFields(t *testing.T) {
+	now := time.Date(2024, 5, 6, 7, 8, 9, 10, time.FixedZone("X", 3600))
+	tests := []struct {
+		field    Field
+		typ      FieldType
+		expected any
+	}{
+		{String("k", "v"), StringType, "v"},
+		{Int("k", -1), Int64Type, int64(-1)},
+		{Int64("k", math.MaxInt64), Int64Type, int64(math.MaxInt64)},
+		{Float64("k", 1.5), Float64Type, 1.5},
+		{Float64("k", math.Inf(-1)), Float64Type, math.Inf(-1)},
+		{Bool("k", true), BoolType, true},
+		{Bool("k", false), BoolType, false},
+		{Duration("k", time.Second), DurationType, time.Second},
+		{Time("k", now), TimeType, now},
+		{Time("k", time.Time{}), AnyType, time.Time{}}, // Out of range for UnixNano.
+		{Bytes("k", []byte("b")), BytesType, []byte("b")},
+		{Object("k", map[string]int{"a": 1}), ObjectType, map[string]int{"a": 1}},
+	}
+	for _, tt := range tests {
+		if tt.field.Key != "k" || tt.field.Type != tt.typ {
+			t.Errorf("Expected key k and type %d, got %+v", tt.typ, tt.field)
+		}
+		got := tt.field.Any()
+		if gotTime, ok := got.(time.Time); ok {
+			if !gotTime.Equal(tt.expected.(time.Time)) || gotTime.Location().String() != tt.expected.(time.Time).Location().String() {
+				t.Errorf("Expected %v, got %v", tt.expected, got)
+			}
+			continue
+		}
+		if !reflect.DeepEqual(got, tt.expected) {
+			t.Errorf("Expected %v (%T), got %v (%T)", tt.expected, tt.expected, got, got)
+		}
+	}
+}
+
+func TestGroup(t *testing.T) {
+	fields := []Field{String("method", "GET"), Int("status", 200)}
+	f := Group("http", fields...)
+	if f.Key != "http" || f.Type != GroupType || !reflect.DeepEqual(f.Group(), fields) {
+		t.Errorf("Unexpected group field: %+v", f)
+	}
+	if got := String("k", "v").Group(); got != nil {
+		t.Errorf("Expected no fields for a string field, got %v", got)
+	}
+}
@@ -0,0 +1,231 @@
+package log
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"time"
+)
+
+// FileConfig is the serializable form of Config, e.g.
+//
+//	{
+//	  "backend": "zap",
+//	  "level": "info",
+//	  "format": "json",
+//	  "outputs": ["stdout", "/var/log/app.log"],
+//	  "caller": {"enabled": true},
+//	  "stacktrace": {"enabled": true, "level": "error"},
+//	  "attrs": {"service": "api"},
+//	  "loggers": {"db": "debug"}
+//	}
+//
+// Omitted fields keep the values of DefaultConfig.
+type FileConfig struct {
+	Backend    string            `json:"backend,omitempty"` // Name of the backend used by New.
+	Level      *Level            `json:"level,omitempty"`
+	Format     OutputFormat      `json:"format,omitempty"`
+	Outputs    []string          `json:"outputs,omitempty"` // "stdout", "stderr" or file paths.
+	Caller     *Caller           `json:"caller,omitempty"`
+	Stacktrace *Stacktrace       `json:"stacktrace,omitempty"`
+	Attrs      map[string]string `json:"attrs,omitempty"`
+	Loggers    map[string]Level  `json:"loggers,omitempty"` // Levels of named loggers.
+}
+
+// ParseFileConfig parses a FileConfig from JSON. Unknown fields are rejected.
+func ParseFileConfig(data []byte) (FileConfig, error) {
+	var fc FileConfig
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&fc); err != nil {
+		return FileConfig{}, fmt.Errorf("invalid config: %w", err)
+	}
+	return fc, nil
+}
+
+// Config returns DefaultConfig() overridden by the fields set in fc.
+// It opens the outputs, which the caller is responsible for closing.
+func (fc FileConfig) Config() (Config, error) {
+	config := DefaultConfig()
+	if fc.Backend != "" {
+		config.Backend = fc.Backend
+	}
+	if fc.Level != nil {
+		config.LogLevel = *fc.Level
+	}
+	if fc.Format != "" {
+		config.OutputFormat = fc.Format
+	}
+	if fc.Caller != nil {
+		config.Caller = *fc.Caller
+	}
+	if fc.Stacktrace != nil {
+		config.Stacktrace = *fc.Stacktrace
+	}
+	if fc.Attrs != nil {
+		config.Attrs = fc.Attrs
+	}
+	if fc.Loggers != nil {
+		config.NamedLevels = NewNamedLevels(fc.Loggers)
+	}
+	if fc.Outputs != nil {
+		outputs, err := openOutputs(fc.Outputs)
+		if err != nil {
+			return Config{}, err
+		}
+		config.Outputs = outputs
+	}
+	return config, nil
+}
+
+// LoadConfigFile loads a Config from the JSON file at path, see FileConfig.
+func LoadConfigFile(path string) (Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return Config{}, err
+	}
+	fc, err := ParseFileConfig(data)
+	if err != nil {
+		return Config{}, fmt.Errorf("%s: %w", path, err)
+	}
+	config, err := fc.Config()
+	if err != nil {
+		return Config{}, fmt.Errorf("%s: %w", path, err)
+	}
+	return config, nil
+}
+
+// ConfigWatcher polls a JSON config file and applies changes of the level,
+// the levels of named loggers, the attributes and the outputs to all loggers
+// built from its Config, without restarting them. Changes of the other fields
+// only apply to loggers built after the change.
+type ConfigWatcher struct {
+	path    string
+	onError func(error)
+
+	level  *AtomicLevel
+	named  *NamedLevels
+	attrs  *AtomicAttrs
+	writer *AtomicWriter
+
+	mu      sync.Mutex
+	config  Config
+	modTime time.Time
+	size    int64
+
+	stop chan struct{}
+	done chan struct{}
+}
+
+// WatchConfigFile loads the JSON config file at path and polls it for changes every interval.
+// Errors while reloading the file keep the current configuration and are passed to onError,
+// or written to os.Stderr if onError is nil. Close stops polling.
+// It returns an error if interval is not positive.
+func WatchConfigFile(path string, interval time.Duration, onError func(error)) (*ConfigWatcher, error) {
+	if interval <= 0 {
+		return nil, fmt.Errorf("invalid poll interval: %v", interval)
+	}
+	if onError == nil {
+		onError = func(err error) {
+			fmt.Fprintf(os.Stderr, "golog: failed to reload config: %v\n", err)
+		}
+	}
+	w := &ConfigWatcher{
+		path:    path,
+		onError: onError,
+		level:   NewAtomicLevel(Info),
+		named:   NewNamedLevels(nil),
+		attrs:   NewAtomicAttrs(nil),
+		writer:  NewAtomicWriter(),
+		stop:    make(chan struct{}),
+		done:    make(chan struct{}),
+	}
+	if _, err := w.reload(true); err != nil {
+		return nil, err
+	}
+	go w.poll(interval)
+	return w, nil
+}
+
+// Config returns the configuration to build loggers following the config file.
+func (w *ConfigWatcher) Config() Config {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.config
+}
+
+// Reload reloads the config file if it changed since it was last loaded.
+// It reports whether the configuration was reloaded.
+func (w *ConfigWatcher) Reload() (bool, error) {
+	return w.reload(false)
+}
+
+// Close stops polling and closes the outputs opened for the config file.
+func (w *ConfigWatcher) Close() error {
+	select {
+	case <-w.stop:
+		return nil
+	default:
+	}
+	close(w.stop)
+	<-w.done
+	closeOutputs(w.writer.Swap())
+	return nil
+}
+
+// poll reloads the config file every interval until Close is called.
+func (w *ConfigWatcher) poll(interval time.Duration) {
+	defer close(w.done)
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-w.stop:
+			return
+		case <-ticker.C:
+			if _, err := w.reload(false); err != nil {
+				w.onError(err)
+			}
+		}
+	}
+}
+
+// reload loads the config file if it changed, or unconditionally if force is set,
+// and applies it.
+func (w *ConfigWatcher) reload(force bool) (bool, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	info, err := os.Stat(w.path)
+	if err != nil {
+		return false, err
+	}
+	if !force && info.ModTime().Equal(w.modTime) && info.Size() == w.size {
+		return false, nil
+	}
+	config, err := LoadConfigFile(w.path)
+	if err != nil {
+		return false, err
+	}
+	w.modTime, w.size = info.ModTime(), info.Size()
+
+	w.level.SetLevel(config.LogLevel)
+	levels := map[string]Level{}
+	if config.NamedLevels != nil {
+		levels = config.NamedLevels.Levels()
+	}
+	w.named.Reset(levels)
+	w.attrs.Store(config.Attrs)
+	closeOutputs(w.writer.Swap(config.Outputs...))
+
+	config.Level = w.level
+	config.NamedLevels = w.named
+	config.Attrs = nil
+	config.LiveAttrs = w.attrs
+	config.Outputs = []io.Writer{w.writer}
+	w.config = config
+	return true, nil
+}
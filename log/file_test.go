@@ -0,0 +1,196 @@
+package log
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+// writeFile writes data to path and moves its modification time forward,
+// so that the change is detected regardless of the file system's time resolution.
+func writeFile(t *testing.T, path, data string) {
+	t.Helper()
+	info, statErr := os.Stat(path)
+	if err := os.WriteFile(path, []byte(data), 0o644); err != nil {
+		t.Fatalf("Failed to write %s: %v", path, err)
+	}
+	if statErr == nil {
+		modTime := info.ModTime().Add(time.Second)
+		if err := os.Chtimes(path, modTime, modTime); err != nil {
+			t.Fatalf("Failed to change times of %s: %v", path, err)
+		}
+	}
+}
+
+func TestParseFileConfig(t *testing.T) {
+	fc, err := ParseFileConfig([]byte(`{
+		"backend": "slog",
+		"level": "warn",
+		"format": "json",
+		"outputs": ["stderr"],
+		"caller": {"enabled": false},
+		"stacktrace": {"enabled": true, "level": "critical"},
+		"attrs": {"service": "api"},
+		"loggers": {"db.*": "error"}
+	}`))
+	if err != nil {
+		t.Fatalf("ParseFileConfig returned an unexpected error: %v", err)
+	}
+
+	config, err := fc.Config()
+	if err != nil {
+		t.Fatalf("Config returned an unexpected error: %v", err)
+	}
+	if config.LogLevel != Warn || config.OutputFormat != OutputFormatJSON {
+		t.Errorf("Unexpected level or format: %v, %v", config.LogLevel, config.OutputFormat)
+	}
+	if len(config.Outputs) != 1 || config.Outputs[0] != os.Stderr {
+		t.Errorf("Expected stderr as output, got %v", config.Outputs)
+	}
+	if config.Caller.Enabled {
+		t.Errorf("Expected caller to be disabled")
+	}
+	if !config.Stacktrace.Enabled || config.Stacktrace.Level != Critical {
+		t.Errorf("Unexpected stack trace config: %+v", config.Stacktrace)
+	}
+	if !reflect.DeepEqual(config.Attrs, map[string]string{"service": "api"}) {
+		t.Errorf("Unexpected attrs: %v", config.Attrs)
+	}
+	if level := config.LevelFor("db.pool"); level != Error {
+		t.Errorf("Expected db.pool level error, got %v", level)
+	}
+	if config.Backend != "slog" {
+		t.Errorf("Expected backend slog, got %q", config.Backend)
+	}
+}
+
+func TestParseFileConfig_Invalid(t *testing.T) {
+	inputs := []string{
+		`{"level": "verbose"}`,
+		`{"format": "xml"}`,
+		`{"lvl": "info"}`,
+		`{`,
+	}
+
+	for _, input := range inputs {
+		t.Run(input, func(t *testing.T) {
+			if _, err := ParseFileConfig([]byte(input)); err == nil {
+				t.Errorf("ParseFileConfig(%s) expected an error but got none", input)
+			}
+		})
+	}
+}
+
+func TestLoadConfigFile_Defaults(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "log.json")
+	writeFile(t, path, `{}`)
+
+	config, err := LoadConfigFile(path)
+	if err != nil {
+		t.Fatalf("LoadConfigFile returned an unexpected error: %v", err)
+	}
+	expected := DefaultConfig()
+	if config.LogLevel != expected.LogLevel || config.Caller != expected.Caller || len(config.Outputs) != 1 {
+		t.Errorf("Expected the default config, got %+v", config)
+	}
+}
+
+func TestWatchConfigFile(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "log.json")
+	first := filepath.Join(dir, "first.log")
+	second := filepath.Join(dir, "second.log")
+	writeFile(t, path, `{"level": "info", "outputs": ["`+first+`"], "attrs": {"version": "1"}}`)
+
+	w, err := WatchConfigFile(path, time.Hour, nil)
+	if err != nil {
+		t.Fatalf("WatchConfigFile returned an unexpected error: %v", err)
+	}
+	defer w.Close()
+
+	config := w.Config()
+	if level := config.LevelFor(""); level != Info {
+		t.Errorf("Expected level info, got %v", level)
+	}
+	if attrs := config.LiveAttrs.KeysAndValues(); !reflect.DeepEqual(attrs, []any{"version", "1"}) {
+		t.Errorf("Unexpected attrs: %v", attrs)
+	}
+	config.Outputs[0].Write([]byte("before\n"))
+
+	writeFile(t, path, `{"level": "error", "outputs": ["`+second+`"], "attrs": {"version": "2"}, "loggers": {"db": "debug"}}`)
+	if reloaded, err := w.Reload(); err != nil || !reloaded {
+		t.Fatalf("Reload() = %v, %v, expected a reload", reloaded, err)
+	}
+
+	if level := config.LevelFor(""); level != Error {
+		t.Errorf("Expected level error after reload, got %v", level)
+	}
+	if level := config.LevelFor("db"); level != Debug {
+		t.Errorf("Expected db level debug after reload, got %v", level)
+	}
+	if attrs := config.LiveAttrs.KeysAndValues(); !reflect.DeepEqual(attrs, []any{"version", "2"}) {
+		t.Errorf("Unexpected attrs after reload: %v", attrs)
+	}
+	config.Outputs[0].Write([]byte("after\n"))
+
+	if data, _ := os.ReadFile(first); string(data) != "before\n" {
+		t.Errorf("Unexpected content of first output: %q", data)
+	}
+	if data, _ := os.ReadFile(second); string(data) != "after\n" {
+		t.Errorf("Unexpected content of second output: %q", data)
+	}
+
+	if reloaded, err := w.Reload(); err != nil || reloaded {
+		t.Errorf("Reload() of an unchanged file = %v, %v, expected no reload", reloaded, err)
+	}
+}
+
+func TestWatchConfigFile_InvalidChangeKeepsConfig(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "log.json")
+	writeFile(t, path, `{"level": "warn", "outputs": ["stdout"]}`)
+
+	errs := make(chan error, 1)
+	w, err := WatchConfigFile(path, time.Millisecond, func(err error) {
+		select {
+		case errs <- err:
+		default:
+		}
+	})
+	if err != nil {
+		t.Fatalf("WatchConfigFile returned an unexpected error: %v", err)
+	}
+	defer w.Close()
+
+	writeFile(t, path, `{"level": "verbose"}`)
+	select {
+	case err := <-errs:
+		if !strings.Contains(err.Error(), "verbose") {
+			t.Errorf("Unexpected error: %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Expected the watcher to report an error")
+	}
+	config := w.Config()
+	if level := config.LevelFor(""); level != Warn {
+		t.Errorf("Expected level to stay warn, got %v", level)
+	}
+}
+
+func TestWatchConfigFile_MissingFile(t *testing.T) {
+	if _, err := WatchConfigFile(filepath.Join(t.TempDir(), "missing.json"), time.Second, nil); err == nil {
+		t.Errorf("Expected an error for a missing file")
+	}
+}
+
+func TestWatchConfigFile_InvalidInterval(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "golog.json")
+	writeFile(t, path, `{"level": "info"}`)
+	for _, interval := range []time.Duration{0, -time.Second} {
+		if _, err := WatchConfigFile(path, interval, nil); err == nil {
+			t.Errorf("Expected an error for interval %v", interval)
+		}
+	}
+}
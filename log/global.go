@@ -0,0 +1,57 @@
+package log
+
+import "context"
+
+// The following functions log with the default logger returned by Default.
+// Caller information points at their caller. They are named after the levels
+// with a Context suffix since the level names are taken by the Level constants.
+
+// TraceContext logs at Trace level with the default logger.
+func TraceContext(ctx context.Context, msg string, keysAndValues ...any) {
+	loadDefault().skip.Trace(ctx, msg, keysAndValues...)
+}
+
+// DebugContext logs at Debug level with the default logger.
+func DebugContext(ctx context.Context, msg string, keysAndValues ...any) {
+	loadDefault().skip.Debug(ctx, msg, keysAndValues...)
+}
+
+// InfoContext logs at Info level with the default logger.
+func InfoContext(ctx context.Context, msg string, keysAndValues ...any) {
+	loadDefault().skip.Info(ctx, msg, keysAndValues...)
+}
+
+// NoticeContext logs at Notice level with the default logger.
+func NoticeContext(ctx context.Context, msg string, keysAndValues ...any) {
+	loadDefault().skip.Notice(ctx, msg, keysAndValues...)
+}
+
+// WarnContext logs at Warn level with the default logger.
+func WarnContext(ctx context.Context, msg string, keysAndValues ...any) {
+	loadDefault().skip.Warn(ctx, msg, keysAndValues...)
+}
+
+// ErrorContext logs at Error level with the default logger.
+func ErrorContext(ctx context.Context, msg string, keysAndValues ...any) {
+	loadDefault().skip.Error(ctx, msg, keysAndValues...)
+}
+
+// CriticalContext logs at Critical level with the default logger.
+func CriticalContext(ctx context.Context, msg string, keysAndValues ...any) {
+	loadDefault().skip.Critical(ctx, msg, keysAndValues...)
+}
+
+// PanicContext logs at Panic level with the default logger and then panics with msg.
+func PanicContext(ctx context.Context, msg string, keysAndValues ...any) {
+	loadDefault().skip.Panic(ctx, msg, keysAndValues...)
+}
+
+// FatalContext logs at Fatal level with the default logger and then calls os.Exit(1).
+func FatalContext(ctx context.Context, msg string, keysAndValues ...any) {
+	loadDefault().skip.Fatal(ctx, msg, keysAndValues...)
+}
+
+// Enabled reports whether the default logger writes entries at level.
+func Enabled(ctx context.Context, level Level) bool {
+	return loadDefault().logger.Enabled(ctx, level)
+}
@@ -0,0 +1,77 @@
+package log
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+func TestPackageFunctions(t *testing.T) {
+	logger := &recordingLogger{level: Info}
+	SetDefault(logger)
+	defer SetDefault(nil)
+
+	ctx := context.Background()
+	DebugContext(ctx, "debug")
+	InfoContext(ctx, "info")
+
+	if len(logger.messages) != 2 || logger.messages[1] != "info" {
+		t.Errorf("Unexpected messages: %v", logger.messages)
+	}
+	if logger.skip != 1 {
+		t.Errorf("Expected the package functions to skip 1 caller frame, got %d", logger.skip)
+	}
+	if Enabled(ctx, Debug) || !Enabled(ctx, Info) {
+		t.Errorf("Expected Enabled to follow the default logger")
+	}
+}
+
+func TestPackageFunctions_WithoutBackend(t *testing.T) {
+	// DefaultBackend is not registered in this package's tests.
+	if _, ok := Default().(*fallbackLogger); !ok {
+		t.Errorf("Expected the built-in fallback logger without a registered default backend, got %v", Default())
+	}
+}
+
+func TestFallbackLogger(t *testing.T) {
+	var buf bytes.Buffer
+	logger := newFallbackLogger(&buf)
+	ctx := ContextWithFields(context.Background(), "request_id", "abc")
+	var typedNil *ptrError
+
+	logger.Named("db").WithGroup("http").With("method", "GET").Error(ctx, "Request failed", "status", 500, "error", errors.New("bad gateway"), "nil", typedNil, "user")
+	logger.Debug(ctx, "Discarded")
+
+	out := buf.String()
+	for _, expected := range []string{"level=ERROR", "logger=db", `msg="Request failed"`, " request_id=abc", "http.method=GET", "http.status=500", `http.error.msg="bad gateway"`, "http.error.type=*errors.errorString", "http.nil=<nil>", "http.user=!MISSING"} {
+		if !strings.Contains(out, expected) {
+			t.Errorf("Expected %s in log output, got: %s", expected, out)
+		}
+	}
+	if strings.Count(out, "\n") != 1 || strings.Contains(out, "Discarded") {
+		t.Errorf("Expected a single entry at Info level and above, got: %s", out)
+	}
+}
+
+func TestFallbackLogger_Strict(t *testing.T) {
+	var errs []error
+	logger := newFallbackLogger(io.Discard)
+	logger.config.Strict = true
+	logger.config.OnError = func(err error) { errs = append(errs, err) }
+
+	logger.Info(context.Background(), "Malformed", "user")
+	_, file, line, _ := runtime.Caller(0)
+
+	var kvErr *KeysAndValuesError
+	if len(errs) != 1 || !errors.As(errs[0], &kvErr) {
+		t.Fatalf("Expected a single *KeysAndValuesError, got %v", errs)
+	}
+	if expected := fmt.Sprintf("%s:%d ", file, line-1); !strings.HasPrefix(kvErr.Caller, expected) {
+		t.Errorf("Expected the caller %s, got %s", expected, kvErr.Caller)
+	}
+}
@@ -0,0 +1,203 @@
+package log
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// LevelHandler is an http.Handler to view and change log levels at runtime.
+//
+// GET responds with the current levels as JSON, e.g.
+//
+//	{"level":"info","loggers":{"db":"debug"}}
+//
+// PUT and POST change a level and respond like GET. The request is either a
+// JSON body or form values with the following fields:
+//   - level: the new level, parsed with ParseLevel. Required.
+//   - logger: name of the logger whose level is changed. The root level is changed if empty.
+//   - duration: optional time.ParseDuration string after which the previous level is restored.
+type LevelHandler struct {
+	level *AtomicLevel
+	named *NamedLevels
+
+	mu      sync.Mutex
+	pending map[string]*pendingRevert // Keyed by logger name, "" for the root level.
+}
+
+// pendingRevert is a level change that is reverted when timer fires.
+type pendingRevert struct {
+	timer *time.Timer
+	prev  Level
+	isSet bool // Whether prev was set for the logger, i.e. it did not inherit its level.
+}
+
+// levelRequest is the body of a PUT or POST request.
+type levelRequest struct {
+	Level    string `json:"level"`
+	Logger   string `json:"logger"`
+	Duration string `json:"duration"`
+}
+
+// levelResponse is the body of every response.
+type levelResponse struct {
+	Level   string            `json:"level,omitempty"`
+	Loggers map[string]string `json:"loggers,omitempty"`
+	Error   string            `json:"error,omitempty"`
+}
+
+// NewLevelHandler returns a LevelHandler serving level and, if not nil, the named levels.
+// An unset level is reported as Info, the default of Config.LogLevel that loggers fall
+// back to, see Config.LevelFor. NewLevelHandler panics if level is nil.
+func NewLevelHandler(level *AtomicLevel, named *NamedLevels) *LevelHandler {
+	if level == nil {
+		panic("log: NewLevelHandler with nil level")
+	}
+	return &LevelHandler{
+		level:   level,
+		named:   named,
+		pending: make(map[string]*pendingRevert),
+	}
+}
+
+// ServeHTTP implements http.Handler.
+func (h *LevelHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		h.writeLevels(w)
+	case http.MethodPut, http.MethodPost:
+		req, err := decodeLevelRequest(r)
+		if err == nil {
+			err = h.apply(req)
+		}
+		if err != nil {
+			writeLevelResponse(w, http.StatusBadRequest, levelResponse{Error: err.Error()})
+			return
+		}
+		h.writeLevels(w)
+	default:
+		w.Header().Set("Allow", "GET, PUT, POST")
+		writeLevelResponse(w, http.StatusMethodNotAllowed, levelResponse{Error: "method not allowed: " + r.Method})
+	}
+}
+
+// decodeLevelRequest decodes a JSON body or form values.
+func decodeLevelRequest(r *http.Request) (levelRequest, error) {
+	var req levelRequest
+	if strings.HasPrefix(r.Header.Get("Content-Type"), "application/json") {
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			return req, fmt.Errorf("invalid JSON body: %w", err)
+		}
+		return req, nil
+	}
+	req.Level = r.FormValue("level")
+	req.Logger = r.FormValue("logger")
+	req.Duration = r.FormValue("duration")
+	return req, nil
+}
+
+// apply changes a level as requested and schedules its revert if a duration is given.
+func (h *LevelHandler) apply(req levelRequest) error {
+	if req.Level == "" {
+		return errors.New("missing level")
+	}
+	level, err := ParseLevel(req.Level)
+	if err != nil {
+		return err
+	}
+	var duration time.Duration
+	if req.Duration != "" {
+		duration, err = time.ParseDuration(req.Duration)
+		if err != nil {
+			return fmt.Errorf("invalid duration: %w", err)
+		}
+		if duration <= 0 {
+			return fmt.Errorf("invalid duration: %s must be positive", req.Duration)
+		}
+	}
+	name := normalizeName(req.Logger)
+	if name != "" && h.named == nil {
+		return errors.New("named logger levels are not supported")
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	// A new change replaces a pending revert but keeps the level to revert to.
+	p, ok := h.pending[name]
+	if ok {
+		p.timer.Stop()
+		delete(h.pending, name)
+	} else {
+		p = &pendingRevert{}
+		p.prev, p.isSet = h.get(name)
+	}
+
+	h.set(name, level, true)
+
+	if duration > 0 {
+		p.timer = time.AfterFunc(duration, func() { h.revert(name, p) })
+		h.pending[name] = p
+	}
+	return nil
+}
+
+// revert restores the level held by p unless p has been replaced meanwhile.
+func (h *LevelHandler) revert(name string, p *pendingRevert) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.pending[name] != p {
+		return
+	}
+	delete(h.pending, name)
+	h.set(name, p.prev, p.isSet)
+}
+
+// get returns the level set for name and whether it is set.
+// The root level is always set.
+func (h *LevelHandler) get(name string) (Level, bool) {
+	if name == "" {
+		return h.level.Level(), true
+	}
+	level, ok := h.named.Levels()[name]
+	return level, ok
+}
+
+// set sets the level for name, or unsets it if isSet is false.
+func (h *LevelHandler) set(name string, level Level, isSet bool) {
+	switch {
+	case name == "":
+		h.level.SetLevel(level)
+	case isSet:
+		h.named.SetLevel(name, level)
+	default:
+		h.named.Unset(name)
+	}
+}
+
+// writeLevels writes the current levels.
+func (h *LevelHandler) writeLevels(w http.ResponseWriter) {
+	level := h.level.Level()
+	if level == 0 {
+		level = DefaultConfig().LogLevel
+	}
+	resp := levelResponse{Level: level.String()}
+	if h.named != nil {
+		resp.Loggers = make(map[string]string)
+		for name, level := range h.named.Levels() {
+			resp.Loggers[name] = level.String()
+		}
+	}
+	writeLevelResponse(w, http.StatusOK, resp)
+}
+
+// writeLevelResponse writes resp as JSON with the given status code.
+func writeLevelResponse(w http.ResponseWriter, status int, resp levelResponse) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(resp)
+}
@@ -0,0 +1,145 @@
+package log
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+)
+
+// serveLevel sends a request to h and decodes the response.
+func serveLevel(t *testing.T, h http.Handler, method, contentType, body string) (int, levelResponse) {
+	t.Helper()
+	req := httptest.NewRequest(method, "/log/level", strings.NewReader(body))
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+
+	var resp levelResponse
+	if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	return rec.Code, resp
+}
+
+func TestLevelHandler_Get(t *testing.T) {
+	named := NewNamedLevels(map[string]Level{"db": Debug})
+	h := NewLevelHandler(NewAtomicLevel(Info), named)
+
+	code, resp := serveLevel(t, h, http.MethodGet, "", "")
+	if code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d", code)
+	}
+	if resp.Level != "info" || resp.Loggers["db"] != "debug" {
+		t.Errorf("Unexpected response: %+v", resp)
+	}
+}
+
+func TestLevelHandler_PutJSON(t *testing.T) {
+	level := NewAtomicLevel(Info)
+	h := NewLevelHandler(level, nil)
+
+	code, resp := serveLevel(t, h, http.MethodPut, "application/json", `{"level":"ERROR"}`)
+	if code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d: %+v", code, resp)
+	}
+	if level.Level() != Error || resp.Level != "error" {
+		t.Errorf("Expected level error, got %v and response %+v", level.Level(), resp)
+	}
+}
+
+func TestLevelHandler_PostForm(t *testing.T) {
+	named := NewNamedLevels(nil)
+	h := NewLevelHandler(NewAtomicLevel(Info), named)
+
+	form := url.Values{"level": {"DEBUG"}, "logger": {"db.*"}}
+	code, resp := serveLevel(t, h, http.MethodPost, "application/x-www-form-urlencoded", form.Encode())
+	if code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d: %+v", code, resp)
+	}
+	if level, ok := named.Level("db.pool"); !ok || level != Debug {
+		t.Errorf("Expected db.pool to be debug, got %v, %v", level, ok)
+	}
+}
+
+func TestLevelHandler_RevertAfterDuration(t *testing.T) {
+	level := NewAtomicLevel(Info)
+	named := NewNamedLevels(nil)
+	h := NewLevelHandler(level, named)
+
+	serveLevel(t, h, http.MethodPut, "application/json", `{"level":"DEBUG","duration":"10ms"}`)
+	serveLevel(t, h, http.MethodPut, "application/json", `{"level":"DEBUG","logger":"db","duration":"10ms"}`)
+	if level.Level() != Debug {
+		t.Fatalf("Expected level debug, got %v", level.Level())
+	}
+
+	deadline := time.Now().Add(time.Second)
+	for level.Level() != Info || len(named.Levels()) != 0 {
+		if time.Now().After(deadline) {
+			t.Fatalf("Expected levels to be reverted, got %v and %v", level.Level(), named.Levels())
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestLevelHandler_Errors(t *testing.T) {
+	tests := []struct {
+		name        string
+		method      string
+		contentType string
+		body        string
+		status      int
+	}{
+		{"missing level", http.MethodPut, "application/json", `{}`, http.StatusBadRequest},
+		{"invalid level", http.MethodPut, "application/json", `{"level":"VERBOSE"}`, http.StatusBadRequest},
+		{"invalid duration", http.MethodPut, "application/json", `{"level":"DEBUG","duration":"soon"}`, http.StatusBadRequest},
+		{"invalid JSON", http.MethodPut, "application/json", `{`, http.StatusBadRequest},
+		{"unsupported logger", http.MethodPut, "application/json", `{"level":"DEBUG","logger":"db"}`, http.StatusBadRequest},
+		{"method not allowed", http.MethodDelete, "", "", http.StatusMethodNotAllowed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := NewLevelHandler(NewAtomicLevel(Info), nil)
+			code, resp := serveLevel(t, h, tt.method, tt.contentType, tt.body)
+			if code != tt.status {
+				t.Errorf("Expected status %d, got %d", tt.status, code)
+			}
+			if resp.Error == "" {
+				t.Errorf("Expected an error message in the response")
+			}
+		})
+	}
+}
+
+func TestLevelHandler_UnsetLevel(t *testing.T) {
+	level := NewAtomicLevel(0)
+	h := NewLevelHandler(level, nil)
+
+	_, resp := serveLevel(t, h, http.MethodGet, "", "")
+	if resp.Level != "info" {
+		t.Fatalf("Expected the unset level to be reported as info, got %+v", resp)
+	}
+	// The response can be sent back to restore the level.
+	body, _ := json.Marshal(levelRequest{Level: resp.Level})
+	if code, resp := serveLevel(t, h, http.MethodPut, "application/json", string(body)); code != http.StatusOK {
+		t.Errorf("Expected status 200, got %d: %+v", code, resp)
+	}
+	if got := level.Level(); got != Info {
+		t.Errorf("Expected level info, got %v", got)
+	}
+}
+
+func TestNewLevelHandler_NilLevel(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Errorf("Expected NewLevelHandler with a nil level to panic")
+		}
+	}()
+	NewLevelHandler(nil, NewNamedLevels(nil))
+}
@@ -0,0 +1,81 @@
+package log
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"runtime"
+
+	"github.com/prakashpandey/golog/internal/stack"
+)
+
+// Keys and values logged in place of a malformed keysAndValues list, see NextField.
+const (
+	BadKey       = "!BADKEY"  // Key of an element in the position of a key that is neither a string nor a Field.
+	MissingValue = "!MISSING" // Value of a trailing string key without a value.
+)
+
+// NextField returns the key and value at keysAndValues[i] as a Field and the index
+// of the next key. It defines how all backends read keysAndValues:
+//
+//   - A Field is returned as is.
+//   - A string is a key followed by its value.
+//   - Any other element is returned as the value of a Field with key BadKey, and the
+//     element after it is the next key.
+//   - A trailing string is returned as the key of a Field with value MissingValue.
+//
+// ok is false for a malformed list, i.e. in the last two cases. Backends may accept
+// their own field types in the position of a key before calling NextField.
+func NextField(keysAndValues []any, i int) (f Field, next int, ok bool) {
+	switch key := keysAndValues[i].(type) {
+	case Field:
+		return key, i + 1, true
+	case string:
+		if i+1 == len(keysAndValues) {
+			return String(key, MissingValue), i + 1, false
+		}
+		return Field{Key: key, Value: keysAndValues[i+1]}, i + 2, true
+	default:
+		return Field{Key: BadKey, Value: key}, i + 1, false
+	}
+}
+
+// KeysAndValuesError reports a malformed keysAndValues list passed to a Logger with
+// Config.Strict set.
+type KeysAndValuesError struct {
+	Caller string // Location of the call that passed the list, e.g. "main.go:42 main.main".
+	Key    any    // The key that is not a string, or the string key without a value.
+}
+
+func (e *KeysAndValuesError) Error() string {
+	if key, ok := e.Key.(string); ok {
+		return fmt.Sprintf("golog: %s: missing value for key %q", e.Caller, key)
+	}
+	return fmt.Sprintf("golog: %s: key %v of type %T is not a string", e.Caller, e.Key, e.Key)
+}
+
+// ReportMalformed passes a *KeysAndValuesError for key, the malformed element found
+// by NextField, to config.OnError if config.Strict is set. It writes the error to
+// os.Stderr if config.OnError is nil.
+//
+// The error names the caller stored in ctx with ContextWithCaller, or else the caller
+// skip stack frames above the function calling ReportMalformed. Backends call it while
+// converting keysAndValues.
+func ReportMalformed(ctx context.Context, config Config, skip int, key any) {
+	if !config.Strict {
+		return
+	}
+	var c stack.Caller
+	if pc, ok := CallerFromContext(ctx); ok {
+		frame, _ := runtime.CallersFrames([]uintptr{pc}).Next()
+		c = stack.Caller{File: frame.File, Line: frame.Line, Function: frame.Function}
+	} else if pc, file, line, ok := runtime.Caller(skip + 1); ok {
+		c = stack.Caller{File: file, Line: line, Function: runtime.FuncForPC(pc).Name()}
+	}
+	err := &KeysAndValuesError{Caller: c.String(), Key: key}
+	if config.OnError == nil {
+		fmt.Fprintln(os.Stderr, err)
+		return
+	}
+	config.OnError(err)
+}
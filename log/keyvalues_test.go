@@ -0,0 +1,83 @@
+package log
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"runtime"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestNextField(t *testing.T) {
+	field := Int("n", 1)
+	tests := []struct {
+		in       []any
+		expected []Field
+		bad      int // Index of the first malformed element, or -1.
+	}{
+		{nil, nil, -1},
+		{[]any{"a", 1}, []Field{{Key: "a", Value: 1}}, -1},
+		{[]any{field, "a", field}, []Field{field, {Key: "a", Value: field}}, -1},
+		{[]any{"a"}, []Field{String("a", MissingValue)}, 0},
+		{[]any{"a", 1, "b"}, []Field{{Key: "a", Value: 1}, String("b", MissingValue)}, 2},
+		{[]any{42, "a", 1}, []Field{{Key: BadKey, Value: 42}, {Key: "a", Value: 1}}, 0},
+		{[]any{nil}, []Field{{Key: BadKey}}, 0},
+	}
+	for _, tt := range tests {
+		var got []Field
+		bad := -1
+		for i := 0; i < len(tt.in); {
+			f, next, ok := NextField(tt.in, i)
+			if !ok && bad < 0 {
+				bad = i
+			}
+			got = append(got, f)
+			i = next
+		}
+		if !reflect.DeepEqual(got, tt.expected) || bad != tt.bad {
+			t.Errorf("NextField(%v) = %v with first malformed element %d, expected %v and %d", tt.in, got, bad, tt.expected, tt.bad)
+		}
+	}
+}
+
+func TestReportMalformed(t *testing.T) {
+	var errs []error
+	config := Config{Strict: true, OnError: func(err error) { errs = append(errs, err) }}
+
+	_, _, line, _ := runtime.Caller(0)
+	ReportMalformed(context.Background(), config, 0, 42)
+	ReportMalformed(context.Background(), config, 0, "key")
+	config.Strict = false
+	ReportMalformed(context.Background(), config, 0, 42)
+
+	if len(errs) != 2 {
+		t.Fatalf("Expected 2 errors, got %v", errs)
+	}
+	var kvErr *KeysAndValuesError
+	if !errors.As(errs[0], &kvErr) || kvErr.Key != 42 {
+		t.Fatalf("Expected a *KeysAndValuesError for 42, got %v", errs[0])
+	}
+	if expected := "keyvalues_test.go:" + strconv.Itoa(line+1); !strings.Contains(kvErr.Caller, expected) {
+		t.Errorf("Expected caller %s, got %s", expected, kvErr.Caller)
+	}
+	if !strings.Contains(errs[0].Error(), "key 42 of type int is not a string") {
+		t.Errorf("Unexpected error: %v", errs[0])
+	}
+	if !strings.Contains(errs[1].Error(), `missing value for key "key"`) {
+		t.Errorf("Unexpected error: %v", errs[1])
+	}
+}
+
+func TestReportMalformed_ContextCaller(t *testing.T) {
+	var err error
+	config := Config{Strict: true, OnError: func(e error) { err = e }}
+	pc, _, line, _ := runtime.Caller(0)
+
+	ReportMalformed(ContextWithCaller(context.Background(), pc), config, 0, 42)
+
+	if expected := "keyvalues_test.go:" + strconv.Itoa(line); err == nil || !strings.Contains(err.Error(), expected) {
+		t.Errorf("Expected caller %s in error, got %v", expected, err)
+	}
+}
@@ -0,0 +1,83 @@
+package log
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// levelNames holds the names of the levels as returned by Level.String.
+var levelNames = map[Level]string{
+	Trace:    "trace",
+	Debug:    "debug",
+	Info:     "info",
+	Notice:   "notice",
+	Warn:     "warn",
+	Error:    "error",
+	Critical: "critical",
+	Panic:    "panic",
+	Fatal:    "fatal",
+	Off:      "off",
+}
+
+// String returns the lower case name of the level, e.g. "info".
+func (l Level) String() string {
+	if name, ok := levelNames[l]; ok {
+		return name
+	}
+	return fmt.Sprintf("Level(%d)", int(l))
+}
+
+// MarshalText implements encoding.TextMarshaler. The zero Level, i.e. an unset
+// level, is encoded as an empty text.
+func (l Level) MarshalText() ([]byte, error) {
+	if l == 0 {
+		return []byte{}, nil
+	}
+	if _, ok := levelNames[l]; !ok {
+		return nil, fmt.Errorf("invalid log level: %d", int(l))
+	}
+	return []byte(l.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler using ParseLevel. An empty
+// text yields the zero Level, i.e. an unset level.
+func (l *Level) UnmarshalText(text []byte) error {
+	if strings.TrimSpace(string(text)) == "" {
+		*l = 0
+		return nil
+	}
+	level, err := ParseLevel(string(text))
+	if err != nil {
+		return err
+	}
+	*l = level
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler. Levels are encoded as their name.
+func (l Level) MarshalJSON() ([]byte, error) {
+	text, err := l.MarshalText()
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(string(text))
+}
+
+// UnmarshalJSON implements json.Unmarshaler. It accepts a level name or a number.
+func (l *Level) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		var n json.Number
+		if err := json.Unmarshal(data, &n); err != nil {
+			return fmt.Errorf("invalid log level: %s", data)
+		}
+		s = n.String()
+	}
+	return l.UnmarshalText([]byte(s))
+}
+
+// Set implements flag.Value using ParseLevel, so that a Level can be used with flag.Var.
+func (l *Level) Set(s string) error {
+	return l.UnmarshalText([]byte(s))
+}
@@ -0,0 +1,126 @@
+package log
+
+import (
+	"encoding/json"
+	"flag"
+	"io"
+	"testing"
+)
+
+func TestLevel_String(t *testing.T) {
+	tests := []struct {
+		level    Level
+		expected string
+	}{
+		{Trace, "trace"},
+		{Info, "info"},
+		{Critical, "critical"},
+		{Off, "off"},
+		{Level(42), "Level(42)"},
+	}
+
+	for _, test := range tests {
+		if got := test.level.String(); got != test.expected {
+			t.Errorf("Level(%d).String() = %q, expected %q", int(test.level), got, test.expected)
+		}
+	}
+}
+
+func TestLevel_TextRoundTrip(t *testing.T) {
+	for level := Trace; level <= Off; level++ {
+		text, err := level.MarshalText()
+		if err != nil {
+			t.Fatalf("MarshalText(%v) returned an unexpected error: %v", level, err)
+		}
+		var got Level
+		if err := got.UnmarshalText(text); err != nil {
+			t.Fatalf("UnmarshalText(%q) returned an unexpected error: %v", text, err)
+		}
+		if got != level {
+			t.Errorf("Round trip of %v through text yielded %v", level, got)
+		}
+	}
+
+	if _, err := Level(42).MarshalText(); err == nil {
+		t.Errorf("Expected MarshalText of an invalid level to fail")
+	}
+}
+
+func TestLevel_Unset(t *testing.T) {
+	type config struct {
+		Level      Level      `json:"level"`
+		Stacktrace Stacktrace `json:"stacktrace"`
+	}
+
+	data, err := json.Marshal(config{Stacktrace: Stacktrace{Enabled: true}})
+	if err != nil {
+		t.Fatalf("json.Marshal returned an unexpected error: %v", err)
+	}
+	if expected := `{"level":"","stacktrace":{"enabled":true,"level":""}}`; string(data) != expected {
+		t.Errorf("json.Marshal() = %s, expected %s", data, expected)
+	}
+
+	c := config{Level: Warn, Stacktrace: Stacktrace{Level: Error}}
+	if err := json.Unmarshal(data, &c); err != nil {
+		t.Fatalf("json.Unmarshal returned an unexpected error: %v", err)
+	}
+	if c.Level != 0 || c.Stacktrace.Level != 0 {
+		t.Errorf("Expected unset levels, got %v and %v", c.Level, c.Stacktrace.Level)
+	}
+}
+
+func TestLevel_JSON(t *testing.T) {
+	type config struct {
+		Level Level `json:"level"`
+	}
+
+	data, err := json.Marshal(config{Level: Warn})
+	if err != nil {
+		t.Fatalf("json.Marshal returned an unexpected error: %v", err)
+	}
+	if string(data) != `{"level":"warn"}` {
+		t.Errorf("Unexpected JSON: %s", data)
+	}
+
+	tests := []struct {
+		input    string
+		expected Level
+		err      bool
+	}{
+		{`{"level":"warn"}`, Warn, false},
+		{`{"level":"ERROR"}`, Error, false},
+		{`{"level":5}`, Warn, false},
+		{`{"level":"verbose"}`, 0, true},
+		{`{"level":true}`, 0, true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.input, func(t *testing.T) {
+			var c config
+			err := json.Unmarshal([]byte(test.input), &c)
+			if (err != nil) != test.err {
+				t.Fatalf("json.Unmarshal(%s) error = %v, expected error = %v", test.input, err, test.err)
+			}
+			if c.Level != test.expected {
+				t.Errorf("json.Unmarshal(%s) = %v, expected %v", test.input, c.Level, test.expected)
+			}
+		})
+	}
+}
+
+func TestLevel_Flag(t *testing.T) {
+	level := Info
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	fs.Var(&level, "level", "log level")
+
+	if err := fs.Parse([]string{"-level", "Debug"}); err != nil {
+		t.Fatalf("Parse returned an unexpected error: %v", err)
+	}
+	if level != Debug {
+		t.Errorf("Expected level debug, got %v", level)
+	}
+	if err := fs.Parse([]string{"-level", "verbose"}); err == nil {
+		t.Errorf("Expected an error for an invalid level")
+	}
+}
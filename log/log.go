@@ -5,41 +5,89 @@ import (
 	"errors"
 	"io"
 	"os"
+	"strconv"
 	"strings"
 	"time"
 )
 
 // Level defines different levels of logging.
+// The zero value means the level is not set, so that Default can tell an unset
+// level apart from an explicit Debug level.
 type Level int
 
 const (
-	Debug Level = iota
-	Info
-	Warn
-	Error
+	Trace    Level = iota + 1 // Finer-grained than Debug.
+	Debug                     // Debugging information.
+	Info                      // Routine information.
+	Notice                    // Normal but significant events.
+	Warn                      // Potential problems.
+	Error                     // Errors.
+	Critical                  // Critical conditions that need immediate attention.
+	Panic                     // Logged before calling panic.
+	Fatal                     // Logged before calling os.Exit(1).
+	Off                       // Threshold that disables logging. Not a level to log at.
 )
 
+// ParseLevel parses a level name, e.g. "info", case-insensitively and ignoring
+// leading and trailing white spaces. The numeric value of a level is accepted too.
 func ParseLevel(levelStr string) (Level, error) {
-	switch levelStr {
-	case "DEBUG", "debug":
-		return Debug, nil
-	case "INFO", "info":
-		return Info, nil
-	case "WARN", "warn":
-		return Warn, nil
-	case "ERROR", "error":
-		return Error, nil
-	default:
-		return 0, errors.New("invalid log level: " + levelStr)
+	s := strings.TrimSpace(levelStr)
+	for level := Trace; level <= Off; level++ {
+		if strings.EqualFold(s, level.String()) {
+			return level, nil
+		}
 	}
+	if n, err := strconv.Atoi(s); err == nil && Level(n) >= Trace && Level(n) <= Off {
+		return Level(n), nil
+	}
+	return 0, errors.New("invalid log level: " + levelStr)
 }
 
 type Logger interface {
+	Trace(ctx context.Context, msg string, keysAndValues ...any)
 	Debug(ctx context.Context, msg string, keysAndValues ...any)
 	Info(ctx context.Context, msg string, keysAndValues ...any)
+	Notice(ctx context.Context, msg string, keysAndValues ...any)
 	Warn(ctx context.Context, msg string, keysAndValues ...any)
 	Error(ctx context.Context, msg string, keysAndValues ...any)
+	Critical(ctx context.Context, msg string, keysAndValues ...any)
+
+	// Panic logs at Panic level and then panics with msg, even if the level is disabled.
+	Panic(ctx context.Context, msg string, keysAndValues ...any)
+
+	// Fatal logs at Fatal level, flushes the outputs and then calls Exit with
+	// Config.ExitFunc and code 1, even if the level is disabled.
 	Fatal(ctx context.Context, msg string, keysAndValues ...any)
+
+	// With returns a child logger that adds keysAndValues to every entry it writes.
+	// The parent logger is not modified.
+	With(keysAndValues ...any) Logger
+
+	// WithGroup returns a child logger that nests all subsequent fields under name.
+	// JSON output yields nested objects and TEXT output yields dotted keys,
+	// e.g. "http.method". An empty name returns the logger unchanged.
+	WithGroup(name string) Logger
+
+	// Named returns a child logger whose name is the parent's name joined with
+	// name by a dot, e.g. "db.pool". The name is emitted in every entry and
+	// selects the logger's minimum level from Config.NamedLevels.
+	Named(name string) Logger
+
+	// Enabled reports whether the logger writes entries at level.
+	Enabled(ctx context.Context, level Level) bool
+
+	// WithCallerSkip returns a child logger that skips skip additional stack frames
+	// when recording caller and stack trace information. It is meant for wrappers
+	// around a Logger, so that caller information points at the wrapper's caller.
+	WithCallerSkip(skip int) Logger
+
+	// Sync flushes the outputs of the logger, see SyncOutputs.
+	Sync() error
+
+	// Close flushes and closes the outputs of the logger, see CloseOutputs.
+	// Outputs are shared with the parent and child loggers, e.g. those returned by
+	// With, so none of them may be used after Close.
+	Close() error
 }
 
 type OutputFormat string
@@ -50,15 +98,15 @@ const (
 )
 
 type Caller struct {
-	FieldName string // Key name for caller in the log. Default is "caller
-	Enabled   bool
-	Skip      int
+	FieldName string `json:"field_name,omitempty"` // Key name for caller in the log. Default is "caller
+	Enabled   bool   `json:"enabled"`
+	Skip      int    `json:"skip,omitempty"` // Number of additional stack frames to skip, e.g. for logging wrappers.
 }
 
 type Stacktrace struct {
-	FieldName string // Key name for stack trace in the log. Default is "stacktrace"
-	Enabled   bool
-	Level     Level // Minimum log level to record stack trace
+	FieldName string `json:"field_name,omitempty"` // Key name for stack trace in the log. Default is "stacktrace"
+	Enabled   bool   `json:"enabled"`
+	Level     Level  `json:"level"` // Minimum log level to record stack trace
 }
 
 // Config holds configuration for the logger, including log level and output format.
@@ -69,7 +117,15 @@ type Config struct {
 	Outputs      []io.Writer       // Output targets, e.g., os.Stdout, os.Stderr
 	OutputFormat OutputFormat      // Output format
 	LogLevel     Level             // Minimum log level
+	Level        *AtomicLevel      // Runtime-adjustable minimum log level. Takes precedence over LogLevel if set.
 	Attrs        map[string]string // Additional attributes to be logged for each log entry.
+	LiveAttrs    *AtomicAttrs      // Additional attributes to be logged for each log entry that can be replaced at runtime.
+	NamedLevels  *NamedLevels      // Minimum log levels for named loggers. Falls back to Level.
+	NameField    string            // Key name for the logger name in the log. Default is "logger"
+	Backend      string            // Name of the backend used by New, e.g. "zap" or "slog".
+	ExitFunc     func(code int)    // Called by Fatal after the hooks registered with OnFatal. Default is os.Exit, PanicOnFatal is meant for tests.
+	Strict       bool              // Report malformed keysAndValues, e.g. a key that is not a string, to OnError. See NextField.
+	OnError      func(err error)   // Called with internal errors such as *KeysAndValuesError. Default writes them to os.Stderr.
 }
 
 func DefaultConfig() Config {
@@ -78,8 +134,9 @@ func DefaultConfig() Config {
 		Outputs:      []io.Writer{os.Stdout},
 		LogLevel:     Info,
 		OutputFormat: OutputFormatTEXT,
-		Caller:       Caller{Enabled: true, Skip: 1},
+		Caller:       Caller{Enabled: true},
 		Stacktrace:   Stacktrace{Enabled: true, Level: Error},
+		Backend:      DefaultBackend,
 	}
 }
 
@@ -87,6 +144,8 @@ func DefaultConfig() Config {
 func (c *Config) Sanitize() {
 	c.Caller.FieldName = strings.TrimSpace(c.Caller.FieldName)
 	c.Stacktrace.FieldName = strings.TrimSpace(c.Stacktrace.FieldName)
+	c.NameField = strings.TrimSpace(c.NameField)
+	c.Backend = strings.TrimSpace(c.Backend)
 }
 
 // Default sets default values for the logger configuration.
@@ -96,8 +155,14 @@ func (c *Config) Sanitize() {
 // - TmFn: time.Now
 // - Caller.FieldName: "caller"
 // - Stacktrace.FieldName: "stacktrace"
+// - NameField: "logger"
 // - OutputFormat: OutputFormatTEXT
 // - LogLevel: Info
+// - Level: a new AtomicLevel set to LogLevel
+// - Stacktrace.Level: Error
+// - Backend: DefaultBackend
+// Booleans and Caller.Skip are never changed, so their zero values are explicit.
+// Use NewConfig to start from DefaultConfig instead of the zero Config.
 func (c *Config) Default() {
 	if c.TmFn == nil {
 		c.TmFn = time.Now
@@ -108,10 +173,53 @@ func (c *Config) Default() {
 	if c.Stacktrace.FieldName == "" {
 		c.Stacktrace.FieldName = "stacktrace"
 	}
+	if c.NameField == "" {
+		c.NameField = "logger"
+	}
 	if c.OutputFormat == "" {
 		c.OutputFormat = OutputFormatTEXT
 	}
 	if c.LogLevel == 0 {
 		c.LogLevel = Info
 	}
+	if c.Stacktrace.Level == 0 {
+		c.Stacktrace.Level = Error
+	}
+	if c.Level == nil {
+		c.Level = NewAtomicLevel(c.LogLevel)
+	}
+	if c.Backend == "" {
+		c.Backend = DefaultBackend
+	}
+}
+
+// LevelFor returns the minimum log level for the logger with the given name.
+// It is the level set in NamedLevels for name or its closest ancestor,
+// falling back to Level, or LogLevel if Level is not set.
+func (c *Config) LevelFor(name string) Level {
+	if c.NamedLevels != nil && name != "" {
+		if level, ok := c.NamedLevels.Level(name); ok {
+			return level
+		}
+	}
+	if c.Level != nil {
+		if level := c.Level.Level(); level != 0 {
+			return level
+		}
+	}
+	return c.LogLevel
+}
+
+// MinLevel returns the lowest minimum log level of the logger and its named
+// loggers, i.e. the lowest of LevelFor("") and the levels in NamedLevels.
+// Backends enforce it in their native handlers, since named loggers may log
+// below LevelFor(""), and the exact level of each named logger with LevelFor.
+func (c *Config) MinLevel() Level {
+	level := c.LevelFor("")
+	if c.NamedLevels != nil {
+		if min, ok := c.NamedLevels.minLevel(); ok && min < level {
+			level = min
+		}
+	}
+	return level
 }
@@ -10,10 +10,16 @@ func TestParseLogLevel(t *testing.T) {
 		expected Level
 		err      bool
 	}{
+		{"TRACE", Trace, false},
 		{"DEBUG", Debug, false},
 		{"INFO", Info, false},
+		{"NOTICE", Notice, false},
 		{"WARN", Warn, false},
 		{"ERROR", Error, false},
+		{"CRITICAL", Critical, false},
+		{"PANIC", Panic, false},
+		{"FATAL", Fatal, false},
+		{"OFF", Off, false},
 		{"INVALID", 0, true},
 		{"", 0, true},
 	}
@@ -40,6 +46,15 @@ func TestParseLogLevel_ValidLevels(t *testing.T) {
 		{"INFO", Info},
 		{"WARN", Warn},
 		{"ERROR", Error},
+		{"debug", Debug},
+		{"Info", Info},
+		{"wArN", Warn},
+		{"DEBUG ", Debug},
+		{" INFO", Info},
+		{"\tnotice\n", Notice},
+		{"1", Trace},
+		{"3", Info},
+		{"10", Off},
 	}
 
 	for _, level := range validLevels {
@@ -58,14 +73,11 @@ func TestParseLogLevel_ValidLevels(t *testing.T) {
 func TestParseLogLevel_InvalidLevels(t *testing.T) {
 	invalidLevels := []string{
 		"INVALID",
-		"debug",
-		"info",
-		"warn",
-		"error",
 		"",
 		"123",
-		"DEBUG ",
-		" INFO",
+		"0",
+		"11",
+		"DE BUG",
 	}
 
 	for _, level := range invalidLevels {
@@ -77,3 +89,23 @@ func TestParseLogLevel_InvalidLevels(t *testing.T) {
 		})
 	}
 }
+
+func TestConfig_Default(t *testing.T) {
+	config := Config{}
+	config.Default()
+	if config.LogLevel != Info {
+		t.Errorf("Expected an unset level to default to info, got %v", config.LogLevel)
+	}
+	if config.Stacktrace.Level != Error {
+		t.Errorf("Expected an unset stack trace level to default to error, got %v", config.Stacktrace.Level)
+	}
+
+	config = Config{LogLevel: Debug, Stacktrace: Stacktrace{Level: Debug}}
+	config.Default()
+	if config.LogLevel != Debug || config.LevelFor("") != Debug {
+		t.Errorf("Expected an explicit debug level to survive Default, got %v", config.LogLevel)
+	}
+	if config.Stacktrace.Level != Debug {
+		t.Errorf("Expected an explicit debug stack trace level to survive Default, got %v", config.Stacktrace.Level)
+	}
+}
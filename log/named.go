@@ -0,0 +1,139 @@
+package log
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// NamedLevels holds minimum log levels for named loggers.
+// Names form a dot-separated hierarchy, e.g. "db" is the parent of "db.pool".
+// A level set for a name also applies to all of its descendants unless a
+// descendant has a level of its own. It is safe for concurrent use.
+type NamedLevels struct {
+	mu     sync.RWMutex
+	levels map[string]Level
+}
+
+// NewNamedLevels returns NamedLevels initialized with the given levels.
+// Keys are normalized as in SetLevel.
+func NewNamedLevels(levels map[string]Level) *NamedLevels {
+	n := &NamedLevels{levels: make(map[string]Level, len(levels))}
+	for name, level := range levels {
+		n.levels[normalizeName(name)] = level
+	}
+	return n
+}
+
+// ParseNamedLevels parses a comma separated list of name=level pairs,
+// e.g. "db.*=debug,http=warn". A trailing ".*" on a name is optional since
+// levels always apply to descendants.
+func ParseNamedLevels(spec string) (*NamedLevels, error) {
+	n := NewNamedLevels(nil)
+	for _, pair := range strings.Split(spec, ",") {
+		if strings.TrimSpace(pair) == "" {
+			continue
+		}
+		name, levelStr, ok := strings.Cut(pair, "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid named level %q: expected name=level", pair)
+		}
+		name = normalizeName(name)
+		if name == "" {
+			return nil, fmt.Errorf("invalid named level %q: empty name", pair)
+		}
+		level, err := ParseLevel(strings.TrimSpace(levelStr))
+		if err != nil {
+			return nil, fmt.Errorf("invalid named level %q: %w", pair, err)
+		}
+		n.levels[name] = level
+	}
+	return n, nil
+}
+
+// SetLevel sets the minimum level for the named logger and its descendants.
+func (n *NamedLevels) SetLevel(name string, level Level) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	if n.levels == nil {
+		n.levels = make(map[string]Level)
+	}
+	n.levels[normalizeName(name)] = level
+}
+
+// Reset replaces all levels with the given levels.
+func (n *NamedLevels) Reset(levels map[string]Level) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.levels = make(map[string]Level, len(levels))
+	for name, level := range levels {
+		n.levels[normalizeName(name)] = level
+	}
+}
+
+// Unset removes the level set for name, so that it inherits from its ancestors again.
+func (n *NamedLevels) Unset(name string) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	delete(n.levels, normalizeName(name))
+}
+
+// Level returns the level set for name or its closest ancestor.
+// The second return value is false if neither name nor any ancestor has a level.
+func (n *NamedLevels) Level(name string) (Level, bool) {
+	n.mu.RLock()
+	defer n.mu.RUnlock()
+	for name != "" {
+		if level, ok := n.levels[name]; ok {
+			return level, true
+		}
+		i := strings.LastIndexByte(name, '.')
+		if i < 0 {
+			break
+		}
+		name = name[:i]
+	}
+	return 0, false
+}
+
+// Levels returns a copy of all levels that are set.
+func (n *NamedLevels) Levels() map[string]Level {
+	n.mu.RLock()
+	defer n.mu.RUnlock()
+	levels := make(map[string]Level, len(n.levels))
+	for name, level := range n.levels {
+		levels[name] = level
+	}
+	return levels
+}
+
+// minLevel returns the lowest level that is set.
+// The second return value is false if no level is set.
+func (n *NamedLevels) minLevel() (Level, bool) {
+	n.mu.RLock()
+	defer n.mu.RUnlock()
+	var min Level
+	for _, level := range n.levels {
+		if min == 0 || level < min {
+			min = level
+		}
+	}
+	return min, min != 0
+}
+
+// JoinName joins a parent logger name and a child name with a dot.
+func JoinName(parent, name string) string {
+	if parent == "" {
+		return name
+	}
+	if name == "" {
+		return parent
+	}
+	return parent + "." + name
+}
+
+// normalizeName trims white spaces and an optional trailing ".*" from name.
+func normalizeName(name string) string {
+	name = strings.TrimSpace(name)
+	return strings.TrimSuffix(name, ".*")
+}
@@ -0,0 +1,117 @@
+package log
+
+import (
+	"testing"
+)
+
+func TestNamedLevels_Level(t *testing.T) {
+	levels := NewNamedLevels(map[string]Level{
+		"db.*":    Warn,
+		"db.pool": Debug,
+		"http":    Error,
+	})
+
+	tests := []struct {
+		name     string
+		expected Level
+		found    bool
+	}{
+		{"db", Warn, true},
+		{"db.query", Warn, true},
+		{"db.pool", Debug, true},
+		{"db.pool.conn", Debug, true},
+		{"http", Error, true},
+		{"httpx", 0, false},
+		{"cache", 0, false},
+		{"", 0, false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			level, ok := levels.Level(test.name)
+			if ok != test.found || level != test.expected {
+				t.Errorf("Level(%q) = %v, %v, expected %v, %v", test.name, level, ok, test.expected, test.found)
+			}
+		})
+	}
+}
+
+func TestNamedLevels_SetLevelAndUnset(t *testing.T) {
+	levels := NewNamedLevels(nil)
+	levels.SetLevel("db", Error)
+	if level, ok := levels.Level("db.pool"); !ok || level != Error {
+		t.Errorf("Expected db.pool to inherit Error, got %v, %v", level, ok)
+	}
+
+	levels.Unset("db")
+	if _, ok := levels.Level("db.pool"); ok {
+		t.Errorf("Expected db.pool to have no level after Unset")
+	}
+}
+
+func TestParseNamedLevels(t *testing.T) {
+	levels, err := ParseNamedLevels("db.*=DEBUG, http=WARN")
+	if err != nil {
+		t.Fatalf("ParseNamedLevels returned an unexpected error: %v", err)
+	}
+
+	expected := map[string]Level{"db": Debug, "http": Warn}
+	got := levels.Levels()
+	if len(got) != len(expected) {
+		t.Fatalf("Expected %d levels, got %v", len(expected), got)
+	}
+	for name, level := range expected {
+		if got[name] != level {
+			t.Errorf("Expected level %v for %q, got %v", level, name, got[name])
+		}
+	}
+}
+
+func TestParseNamedLevels_Invalid(t *testing.T) {
+	specs := []string{
+		"db",
+		"=DEBUG",
+		"db=VERBOSE",
+	}
+
+	for _, spec := range specs {
+		t.Run(spec, func(t *testing.T) {
+			if _, err := ParseNamedLevels(spec); err == nil {
+				t.Errorf("ParseNamedLevels(%q) expected an error but got none", spec)
+			}
+		})
+	}
+}
+
+func TestConfig_LevelFor(t *testing.T) {
+	config := Config{
+		LogLevel:    Info,
+		NamedLevels: NewNamedLevels(map[string]Level{"db": Error}),
+	}
+
+	if level := config.LevelFor(""); level != Info {
+		t.Errorf("Expected root level Info, got %v", level)
+	}
+	if level := config.LevelFor("db.pool"); level != Error {
+		t.Errorf("Expected db.pool level Error, got %v", level)
+	}
+	if level := config.LevelFor("http"); level != Info {
+		t.Errorf("Expected http level to fall back to Info, got %v", level)
+	}
+}
+
+func TestJoinName(t *testing.T) {
+	tests := []struct {
+		parent, name, expected string
+	}{
+		{"", "db", "db"},
+		{"db", "pool", "db.pool"},
+		{"db", "", "db"},
+	}
+
+	for _, test := range tests {
+		if got := JoinName(test.parent, test.name); got != test.expected {
+			t.Errorf("JoinName(%q, %q) = %q, expected %q", test.parent, test.name, got, test.expected)
+		}
+	}
+}
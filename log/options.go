@@ -0,0 +1,116 @@
+package log
+
+import (
+	"io"
+	"time"
+)
+
+// Option sets a field of a Config, see NewConfig.
+type Option func(*Config)
+
+// NewConfig returns DefaultConfig() with opts applied, e.g.
+//
+//	log.NewConfig(log.WithLevel(log.Debug), log.WithCaller(false))
+//
+// Every option sets its field explicitly, including zero values such as
+// the Debug level, false booleans and a zero caller skip.
+func NewConfig(opts ...Option) Config {
+	config := DefaultConfig()
+	for _, opt := range opts {
+		opt(&config)
+	}
+	return config
+}
+
+// WithLevel sets the minimum log level.
+func WithLevel(level Level) Option {
+	return func(c *Config) {
+		c.LogLevel = level
+	}
+}
+
+// WithAtomicLevel sets the runtime-adjustable minimum log level.
+func WithAtomicLevel(level *AtomicLevel) Option {
+	return func(c *Config) {
+		c.Level = level
+	}
+}
+
+// WithNamedLevels sets the minimum log levels for named loggers.
+func WithNamedLevels(levels *NamedLevels) Option {
+	return func(c *Config) {
+		c.NamedLevels = levels
+	}
+}
+
+// WithFormat sets the output format.
+func WithFormat(format OutputFormat) Option {
+	return func(c *Config) {
+		c.OutputFormat = format
+	}
+}
+
+// WithOutputs sets the output targets.
+func WithOutputs(outputs ...io.Writer) Option {
+	return func(c *Config) {
+		c.Outputs = outputs
+	}
+}
+
+// WithCaller sets whether caller information is logged.
+func WithCaller(enabled bool) Option {
+	return func(c *Config) {
+		c.Caller.Enabled = enabled
+	}
+}
+
+// WithCallerFrames sets the number of additional stack frames skipped for caller information.
+func WithCallerFrames(skip int) Option {
+	return func(c *Config) {
+		c.Caller.Skip = skip
+	}
+}
+
+// WithStacktrace sets whether stack traces are logged, and from which level on.
+func WithStacktrace(enabled bool, level Level) Option {
+	return func(c *Config) {
+		c.Stacktrace.Enabled = enabled
+		c.Stacktrace.Level = level
+	}
+}
+
+// WithAttrs sets the attributes logged with each entry.
+func WithAttrs(attrs map[string]string) Option {
+	return func(c *Config) {
+		c.Attrs = attrs
+	}
+}
+
+// WithTimeFunc sets the function returning the time of each entry.
+func WithTimeFunc(fn func() time.Time) Option {
+	return func(c *Config) {
+		c.TmFn = fn
+	}
+}
+
+// WithExitFunc sets the function called by Fatal to exit, e.g. PanicOnFatal in tests.
+func WithExitFunc(exit func(code int)) Option {
+	return func(c *Config) {
+		c.ExitFunc = exit
+	}
+}
+
+// WithStrict sets whether malformed keysAndValues are reported to Config.OnError,
+// e.g. to fail tests that log a key without a value.
+func WithStrict(strict bool) Option {
+	return func(c *Config) {
+		c.Strict = strict
+	}
+}
+
+// WithOnError sets the function called with internal errors such as *KeysAndValuesError.
+func WithOnError(onError func(err error)) Option {
+	return func(c *Config) {
+		c.OnError = onError
+	}
+}
@@ -0,0 +1,67 @@
+package log
+
+import (
+	"bytes"
+	"io"
+	"testing"
+	"time"
+)
+
+func TestNewConfig(t *testing.T) {
+	var buf bytes.Buffer
+	now := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	level := NewAtomicLevel(Warn)
+	named := NewNamedLevels(nil)
+
+	config := NewConfig(
+		WithLevel(Debug),
+		WithAtomicLevel(level),
+		WithNamedLevels(named),
+		WithFormat(OutputFormatJSON),
+		WithOutputs(&buf),
+		WithCaller(false),
+		WithCallerFrames(0),
+		WithStacktrace(false, Critical),
+		WithAttrs(map[string]string{"service": "api"}),
+		WithTimeFunc(func() time.Time { return now }),
+		WithStrict(true),
+		WithOnError(func(error) {}),
+	)
+	config.Default()
+
+	if config.LogLevel != Debug {
+		t.Errorf("Expected level debug to survive Default, got %v", config.LogLevel)
+	}
+	if config.Level != level || config.NamedLevels != named {
+		t.Errorf("Expected the given atomic and named levels")
+	}
+	if config.OutputFormat != OutputFormatJSON {
+		t.Errorf("Expected format JSON, got %v", config.OutputFormat)
+	}
+	if len(config.Outputs) != 1 || config.Outputs[0] != io.Writer(&buf) {
+		t.Errorf("Expected the given output, got %v", config.Outputs)
+	}
+	if config.Caller.Enabled || config.Caller.Skip != 0 {
+		t.Errorf("Expected caller to be disabled with zero skip, got %+v", config.Caller)
+	}
+	if config.Stacktrace.Enabled || config.Stacktrace.Level != Critical {
+		t.Errorf("Unexpected stack trace config: %+v", config.Stacktrace)
+	}
+	if config.Attrs["service"] != "api" {
+		t.Errorf("Unexpected attrs: %v", config.Attrs)
+	}
+	if !config.TmFn().Equal(now) {
+		t.Errorf("Expected the given time function")
+	}
+	if !config.Strict || config.OnError == nil {
+		t.Errorf("Expected strict mode with the given error function")
+	}
+}
+
+func TestNewConfig_Defaults(t *testing.T) {
+	config := NewConfig()
+	expected := DefaultConfig()
+	if config.LogLevel != expected.LogLevel || config.Caller != expected.Caller || config.Stacktrace != expected.Stacktrace {
+		t.Errorf("Expected NewConfig without options to equal DefaultConfig, got %+v", config)
+	}
+}
@@ -0,0 +1,134 @@
+package log
+
+import (
+	"context"
+	"runtime"
+	"strings"
+
+	"github.com/prakashpandey/golog/internal/stack"
+)
+
+// Keys of the fields logged by Recover.
+const (
+	PanicKey      = "panic"       // The recovered panic value.
+	PanicStackKey = "panic_stack" // The stack trace of the panicking goroutine.
+)
+
+// RecoverOption configures Recover and Go.
+type RecoverOption func(*recoverOptions)
+
+type recoverOptions struct {
+	level   Level
+	msg     string
+	repanic bool
+}
+
+// RecoverLevel sets the level the panic is logged at. Default is Error.
+// Levels above Critical are logged at Critical.
+func RecoverLevel(level Level) RecoverOption {
+	return func(o *recoverOptions) {
+		o.level = level
+	}
+}
+
+// RecoverMessage sets the message the panic is logged with. Default is "recovered from panic".
+func RecoverMessage(msg string) RecoverOption {
+	return func(o *recoverOptions) {
+		o.msg = msg
+	}
+}
+
+// Repanic makes Recover panic again with the recovered value after logging it.
+func Repanic() RecoverOption {
+	return func(o *recoverOptions) {
+		o.repanic = true
+	}
+}
+
+// Recover recovers a panic and logs it with the panic value under PanicKey and the
+// stack trace of the panicking goroutine, starting at the function that panicked,
+// under PanicStackKey. The caller reported by the backend is that function too.
+// It must be called directly by defer:
+//
+//	defer log.Recover(ctx, logger)
+//
+// A nil logger logs with FromContext(ctx). Recover does nothing without a panic.
+func Recover(ctx context.Context, logger Logger, opts ...RecoverOption) {
+	r := recover()
+	if r == nil {
+		return
+	}
+	handlePanic(ctx, logger, r, opts)
+}
+
+// Go runs fn in a new goroutine and recovers and logs a panic of fn like Recover.
+func Go(ctx context.Context, logger Logger, fn func(ctx context.Context), opts ...RecoverOption) {
+	go func() {
+		defer func() {
+			if r := recover(); r != nil {
+				handlePanic(ctx, logger, r, opts)
+			}
+		}()
+		fn(ctx)
+	}()
+}
+
+// handlePanic logs the recovered value r. It is called by the deferred function
+// that recovered r, while the stack of the panicking goroutine is still intact.
+func handlePanic(ctx context.Context, logger Logger, r any, opts []RecoverOption) {
+	o := recoverOptions{level: Error, msg: "recovered from panic"}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	if logger == nil {
+		logger = FromContext(ctx)
+	}
+
+	pcs := panicCallers()
+	if len(pcs) > 0 {
+		ctx = ContextWithCaller(ctx, pcs[0])
+	}
+	logAt(ctx, logger, o.level, o.msg, PanicKey, r, PanicStackKey, stack.FromPCs(pcs).String())
+
+	if o.repanic {
+		panic(r)
+	}
+}
+
+// panicCallers returns the program counters of the panicking goroutine's stack,
+// starting at the function that panicked. It drops the frames of the deferred
+// function and of the runtime's panic handling above that function.
+func panicCallers() []uintptr {
+	pcs := stack.Callers(1)
+	for i, pc := range pcs {
+		if runtime.FuncForPC(pc-1).Name() != "runtime.gopanic" {
+			continue
+		}
+		// Skip e.g. runtime.panicmem and runtime.sigpanic for runtime errors.
+		for i++; i < len(pcs) && strings.HasPrefix(runtime.FuncForPC(pcs[i]-1).Name(), "runtime."); i++ {
+		}
+		return pcs[i:]
+	}
+	return pcs
+}
+
+// logAt logs msg with logger at level. Levels above Critical are logged at Critical,
+// so that it never panics or exits.
+func logAt(ctx context.Context, logger Logger, level Level, msg string, keysAndValues ...any) {
+	switch level {
+	case Trace:
+		logger.Trace(ctx, msg, keysAndValues...)
+	case Debug:
+		logger.Debug(ctx, msg, keysAndValues...)
+	case Info:
+		logger.Info(ctx, msg, keysAndValues...)
+	case Notice:
+		logger.Notice(ctx, msg, keysAndValues...)
+	case Warn:
+		logger.Warn(ctx, msg, keysAndValues...)
+	case Error:
+		logger.Error(ctx, msg, keysAndValues...)
+	default:
+		logger.Critical(ctx, msg, keysAndValues...)
+	}
+}
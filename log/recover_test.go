@@ -0,0 +1,147 @@
+package log
+
+import (
+	"context"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+// panicEntry is an entry logged by panicLogger.
+type panicEntry struct {
+	level         Level
+	msg           string
+	keysAndValues []any
+	caller        string // Function of the caller stored in the context.
+}
+
+// panicLogger sends the entries logged at Error and Critical level to entries.
+type panicLogger struct {
+	nopLogger
+	entries chan panicEntry
+}
+
+func newPanicLogger() *panicLogger {
+	return &panicLogger{entries: make(chan panicEntry, 1)}
+}
+
+func (l *panicLogger) record(ctx context.Context, level Level, msg string, keysAndValues []any) {
+	e := panicEntry{level: level, msg: msg, keysAndValues: keysAndValues}
+	if pc, ok := CallerFromContext(ctx); ok {
+		e.caller = runtime.FuncForPC(pc - 1).Name()
+	}
+	l.entries <- e
+}
+
+func (l *panicLogger) Error(ctx context.Context, msg string, keysAndValues ...any) {
+	l.record(ctx, Error, msg, keysAndValues)
+}
+
+func (l *panicLogger) Critical(ctx context.Context, msg string, keysAndValues ...any) {
+	l.record(ctx, Critical, msg, keysAndValues)
+}
+
+// panicking panics with value.
+//
+//go:noinline
+func panicking(value any) {
+	panic(value)
+}
+
+// dereferencing panics with a runtime error.
+//
+//go:noinline
+func dereferencing() {
+	var p *int
+	_ = *p
+}
+
+func checkPanicEntry(t *testing.T, e panicEntry, level Level, value string, function string) {
+	t.Helper()
+	if e.level != level {
+		t.Errorf("Expected the panic to be logged at %v, got %v", level, e.level)
+	}
+	if len(e.keysAndValues) != 4 || e.keysAndValues[0] != PanicKey || e.keysAndValues[2] != PanicStackKey {
+		t.Fatalf("Unexpected keys and values: %v", e.keysAndValues)
+	}
+	if got := e.keysAndValues[1]; !strings.Contains(toString(got), value) {
+		t.Errorf("Expected panic value %q, got %v", value, got)
+	}
+	stackTrace, _ := e.keysAndValues[3].(string)
+	if first, _, _ := strings.Cut(stackTrace, "\n"); !strings.HasSuffix(first, function) {
+		t.Errorf("Expected the stack trace to start at %s, got:\n%s", function, stackTrace)
+	}
+	if e.caller != function {
+		t.Errorf("Expected caller %s, got %s", function, e.caller)
+	}
+}
+
+func toString(v any) string {
+	switch v := v.(type) {
+	case string:
+		return v
+	case error:
+		return v.Error()
+	}
+	return ""
+}
+
+func TestRecover(t *testing.T) {
+	tests := []struct {
+		fn       func()
+		value    string
+		function string
+	}{
+		{func() { panicking("boom") }, "boom", "github.com/prakashpandey/golog/log.panicking"},
+		{dereferencing, "nil pointer dereference", "github.com/prakashpandey/golog/log.dereferencing"},
+	}
+	for _, tt := range tests {
+		logger := newPanicLogger()
+		func() {
+			defer Recover(context.Background(), logger)
+			tt.fn()
+		}()
+		e := <-logger.entries
+		if e.msg != "recovered from panic" {
+			t.Errorf("Unexpected message: %s", e.msg)
+		}
+		checkPanicEntry(t, e, Error, tt.value, tt.function)
+	}
+}
+
+func TestRecover_NoPanic(t *testing.T) {
+	logger := newPanicLogger()
+	func() {
+		defer Recover(context.Background(), logger)
+	}()
+	if len(logger.entries) != 0 {
+		t.Errorf("Expected nothing to be logged without a panic")
+	}
+}
+
+func TestRecover_Repanic(t *testing.T) {
+	logger := newPanicLogger()
+	defer func() {
+		if r := recover(); r != "boom" {
+			t.Errorf("Expected the panic to be repeated, got: %v", r)
+		}
+		if len(logger.entries) != 1 {
+			t.Errorf("Expected the panic to be logged before repeating it")
+		}
+	}()
+	defer Recover(context.Background(), logger, Repanic())
+	panicking("boom")
+}
+
+func TestGo(t *testing.T) {
+	logger := newPanicLogger()
+	Go(context.Background(), logger, func(ctx context.Context) {
+		panicking("in goroutine")
+	}, RecoverLevel(Critical), RecoverMessage("worker crashed"))
+
+	e := <-logger.entries
+	if e.msg != "worker crashed" {
+		t.Errorf("Unexpected message: %s", e.msg)
+	}
+	checkPanicEntry(t, e, Critical, "in goroutine", "github.com/prakashpandey/golog/log.panicking")
+}
@@ -0,0 +1,57 @@
+package log
+
+import (
+	"bytes"
+	"context"
+	stdlog "log"
+)
+
+// stdLogSkip is the number of stack frames between a Logger method called by
+// stdWriter and the caller of the standard library logger: logAt, stdWriter.Write,
+// the logger's internal output method and its exported method, e.g. Printf.
+const stdLogSkip = 4
+
+// NewStdLogger returns a standard library logger that writes each line as an
+// entry of logger at level, e.g. for http.Server.ErrorLog. Caller information
+// points at the caller of the returned logger's methods. Levels above Critical
+// are logged at Critical, since the standard library logger's Panic and Fatal
+// methods already panic and exit on their own.
+func NewStdLogger(logger Logger, level Level) *stdlog.Logger {
+	return stdlog.New(newStdWriter(logger, level), "", 0)
+}
+
+// RedirectStdLog redirects the output of the standard library's default logger,
+// e.g. stdlog.Printf, to logger at Info level. It returns a function that restores
+// the previous output, prefix and flags.
+func RedirectStdLog(logger Logger) func() {
+	flags, prefix, output := stdlog.Flags(), stdlog.Prefix(), stdlog.Writer()
+	stdlog.SetFlags(0)
+	stdlog.SetPrefix("")
+	stdlog.SetOutput(newStdWriter(logger, Info))
+	return func() {
+		stdlog.SetFlags(flags)
+		stdlog.SetPrefix(prefix)
+		stdlog.SetOutput(output)
+	}
+}
+
+// stdWriter is the output of a standard library logger that writes to a Logger.
+type stdWriter struct {
+	logger Logger
+	level  Level
+}
+
+func newStdWriter(logger Logger, level Level) *stdWriter {
+	if level < Trace {
+		level = Info
+	}
+	return &stdWriter{logger: logger.WithCallerSkip(stdLogSkip), level: level}
+}
+
+// Write logs p without its trailing newline. The standard library logger calls
+// Write once per line.
+func (w *stdWriter) Write(p []byte) (int, error) {
+	msg := string(bytes.TrimSuffix(p, []byte("\n")))
+	logAt(context.Background(), w.logger, w.level, msg)
+	return len(p), nil
+}
@@ -0,0 +1,37 @@
+package log
+
+import (
+	stdlog "log"
+	"testing"
+)
+
+func TestNewStdLogger(t *testing.T) {
+	logger := &recordingLogger{level: Debug}
+	std := NewStdLogger(logger, Debug)
+
+	std.Printf("request %d failed", 42)
+	std.Print("second line\n")
+
+	if len(logger.messages) != 2 || logger.messages[0] != "request 42 failed" || logger.messages[1] != "second line" {
+		t.Errorf("Unexpected messages: %q", logger.messages)
+	}
+	if logger.skip != stdLogSkip {
+		t.Errorf("Expected the bridge to skip %d caller frames, got %d", stdLogSkip, logger.skip)
+	}
+}
+
+func TestRedirectStdLog(t *testing.T) {
+	flags, prefix, output := stdlog.Flags(), stdlog.Prefix(), stdlog.Writer()
+	logger := &recordingLogger{level: Info}
+
+	restore := RedirectStdLog(logger)
+	stdlog.Println("from the standard library")
+	restore()
+
+	if len(logger.messages) != 1 || logger.messages[0] != "from the standard library" {
+		t.Errorf("Unexpected messages: %q", logger.messages)
+	}
+	if stdlog.Flags() != flags || stdlog.Prefix() != prefix || stdlog.Writer() != output {
+		t.Errorf("Expected restore to reset the standard library logger")
+	}
+}
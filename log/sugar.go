@@ -0,0 +1,91 @@
+package log
+
+import (
+	"context"
+	"fmt"
+)
+
+// Sugar wraps a Logger to provide printf-style logging methods.
+// The message is formatted only if the logger is enabled for the level,
+// and caller information points at the caller of the Sugar method.
+type Sugar struct {
+	logger Logger // Logger skipping the frame of the Sugar method.
+	base   Logger // Logger as passed to NewSugar.
+}
+
+// NewSugar returns a Sugar logging to logger.
+func NewSugar(logger Logger) *Sugar {
+	return &Sugar{
+		logger: logger.WithCallerSkip(1),
+		base:   logger,
+	}
+}
+
+// Desugar returns the Logger wrapped by s.
+func (s *Sugar) Desugar() Logger {
+	return s.base
+}
+
+// With returns a child Sugar that adds keysAndValues to every entry it writes.
+func (s *Sugar) With(keysAndValues ...any) *Sugar {
+	return NewSugar(s.base.With(keysAndValues...))
+}
+
+// Tracef formats a message according to format and logs it at Trace level.
+func (s *Sugar) Tracef(ctx context.Context, format string, args ...any) {
+	if s.logger.Enabled(ctx, Trace) {
+		s.logger.Trace(ctx, fmt.Sprintf(format, args...))
+	}
+}
+
+// Debugf formats a message according to format and logs it at Debug level.
+func (s *Sugar) Debugf(ctx context.Context, format string, args ...any) {
+	if s.logger.Enabled(ctx, Debug) {
+		s.logger.Debug(ctx, fmt.Sprintf(format, args...))
+	}
+}
+
+// Infof formats a message according to format and logs it at Info level.
+func (s *Sugar) Infof(ctx context.Context, format string, args ...any) {
+	if s.logger.Enabled(ctx, Info) {
+		s.logger.Info(ctx, fmt.Sprintf(format, args...))
+	}
+}
+
+// Noticef formats a message according to format and logs it at Notice level.
+func (s *Sugar) Noticef(ctx context.Context, format string, args ...any) {
+	if s.logger.Enabled(ctx, Notice) {
+		s.logger.Notice(ctx, fmt.Sprintf(format, args...))
+	}
+}
+
+// Warnf formats a message according to format and logs it at Warn level.
+func (s *Sugar) Warnf(ctx context.Context, format string, args ...any) {
+	if s.logger.Enabled(ctx, Warn) {
+		s.logger.Warn(ctx, fmt.Sprintf(format, args...))
+	}
+}
+
+// Errorf formats a message according to format and logs it at Error level.
+func (s *Sugar) Errorf(ctx context.Context, format string, args ...any) {
+	if s.logger.Enabled(ctx, Error) {
+		s.logger.Error(ctx, fmt.Sprintf(format, args...))
+	}
+}
+
+// Criticalf formats a message according to format and logs it at Critical level.
+func (s *Sugar) Criticalf(ctx context.Context, format string, args ...any) {
+	if s.logger.Enabled(ctx, Critical) {
+		s.logger.Critical(ctx, fmt.Sprintf(format, args...))
+	}
+}
+
+// Panicf formats a message according to format, logs it and panics with the message.
+func (s *Sugar) Panicf(ctx context.Context, format string, args ...any) {
+	s.logger.Panic(ctx, fmt.Sprintf(format, args...))
+}
+
+// Fatalf formats a message according to format, logs it and calls os.Exit(1).
+func (s *Sugar) Fatalf(ctx context.Context, format string, args ...any) {
+	s.logger.Fatal(ctx, fmt.Sprintf(format, args...))
+}
@@ -0,0 +1,77 @@
+package log
+
+import (
+	"context"
+	"testing"
+)
+
+// recordingLogger records the messages logged through it.
+type recordingLogger struct {
+	nopLogger
+	level    Level
+	skip     int
+	messages []string
+}
+
+func (l *recordingLogger) Enabled(ctx context.Context, level Level) bool {
+	return l.level <= level
+}
+
+func (l *recordingLogger) WithCallerSkip(skip int) Logger {
+	l.skip += skip
+	return l
+}
+
+func (l *recordingLogger) Debug(ctx context.Context, msg string, keysAndValues ...any) {
+	l.messages = append(l.messages, msg)
+}
+
+func (l *recordingLogger) Info(ctx context.Context, msg string, keysAndValues ...any) {
+	l.messages = append(l.messages, msg)
+}
+
+// countingStringer counts how often it is formatted.
+type countingStringer struct {
+	count int
+}
+
+func (s *countingStringer) String() string {
+	s.count++
+	return "value"
+}
+
+func TestSugar_Infof(t *testing.T) {
+	logger := &recordingLogger{level: Info}
+	sugar := NewSugar(logger)
+
+	sugar.Infof(context.Background(), "user %s logged in %d times", "alice", 3)
+
+	if len(logger.messages) != 1 || logger.messages[0] != "user alice logged in 3 times" {
+		t.Errorf("Unexpected messages: %v", logger.messages)
+	}
+	if logger.skip != 1 {
+		t.Errorf("Expected Sugar to skip 1 caller frame, got %d", logger.skip)
+	}
+}
+
+func TestSugar_DisabledLevelDoesNotFormat(t *testing.T) {
+	logger := &recordingLogger{level: Info}
+	sugar := NewSugar(logger)
+	arg := &countingStringer{}
+
+	sugar.Debugf(context.Background(), "debug %s", arg)
+
+	if arg.count != 0 {
+		t.Errorf("Expected message to not be formatted, but it was formatted %d times", arg.count)
+	}
+	if len(logger.messages) != 0 {
+		t.Errorf("Expected no messages, got %v", logger.messages)
+	}
+}
+
+func TestSugar_Desugar(t *testing.T) {
+	logger := &recordingLogger{level: Info}
+	if got := NewSugar(logger).Desugar(); got != logger {
+		t.Errorf("Expected Desugar to return the wrapped logger")
+	}
+}
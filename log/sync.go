@@ -0,0 +1,45 @@
+package log
+
+import (
+	"errors"
+	"io"
+	"os"
+)
+
+// syncer is implemented by outputs that buffer writes, e.g. *os.File and AtomicWriter.
+type syncer interface {
+	Sync() error
+}
+
+// SyncOutputs flushes the outputs that have a Sync() error method and returns their
+// errors joined with errors.Join. Errors of os.Stdout and os.Stderr are ignored,
+// since syncing a terminal or a pipe fails although nothing is lost.
+func SyncOutputs(outputs []io.Writer) error {
+	var errs []error
+	for _, output := range outputs {
+		s, ok := output.(syncer)
+		if !ok {
+			continue
+		}
+		if err := s.Sync(); err != nil && output != os.Stdout && output != os.Stderr {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// CloseOutputs flushes the outputs with SyncOutputs and closes those that
+// implement io.Closer, except os.Stdout and os.Stderr. It returns all errors
+// joined with errors.Join.
+func CloseOutputs(outputs []io.Writer) error {
+	errs := []error{SyncOutputs(outputs)}
+	for _, output := range outputs {
+		if output == os.Stdout || output == os.Stderr {
+			continue
+		}
+		if c, ok := output.(io.Closer); ok {
+			errs = append(errs, c.Close())
+		}
+	}
+	return errors.Join(errs...)
+}
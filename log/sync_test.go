@@ -0,0 +1,53 @@
+package log
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"os"
+	"testing"
+)
+
+// syncingOutput records calls of Sync and Close.
+type syncingOutput struct {
+	bytes.Buffer
+	syncs, closes int
+	err           error
+}
+
+func (o *syncingOutput) Sync() error {
+	o.syncs++
+	return o.err
+}
+
+func (o *syncingOutput) Close() error {
+	o.closes++
+	return nil
+}
+
+func TestSyncOutputs(t *testing.T) {
+	ok := &syncingOutput{}
+	failing := &syncingOutput{err: errors.New("disk full")}
+
+	err := SyncOutputs([]io.Writer{os.Stdout, &bytes.Buffer{}, ok, failing})
+	if !errors.Is(err, failing.err) {
+		t.Errorf("Expected the error of the failing output, got %v", err)
+	}
+	if ok.syncs != 1 || failing.syncs != 1 {
+		t.Errorf("Expected every output to be synced once, got %d and %d", ok.syncs, failing.syncs)
+	}
+	if err := SyncOutputs([]io.Writer{os.Stdout, os.Stderr}); err != nil {
+		t.Errorf("Expected errors of stdout and stderr to be ignored, got %v", err)
+	}
+}
+
+func TestCloseOutputs(t *testing.T) {
+	output := &syncingOutput{}
+	if err := CloseOutputs([]io.Writer{os.Stdout, output, NewAtomicWriter(output)}); err != nil {
+		t.Fatalf("CloseOutputs returned an unexpected error: %v", err)
+	}
+	if output.syncs != 2 || output.closes != 1 {
+		t.Errorf("Expected the output to be synced directly and through the AtomicWriter and closed once, got %d syncs and %d closes",
+			output.syncs, output.closes)
+	}
+}
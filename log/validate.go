@@ -0,0 +1,77 @@
+package log
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+)
+
+// ConfigError describes an invalid field of a Config.
+type ConfigError struct {
+	Field string // Name of the invalid field, e.g. "Outputs[1]".
+	Msg   string // Description of the problem.
+}
+
+// Error implements the error interface.
+func (e *ConfigError) Error() string {
+	return fmt.Sprintf("invalid config: %s: %s", e.Field, e.Msg)
+}
+
+// Validate reports all problems of the configuration as *ConfigError values joined
+// with errors.Join, or nil if there are none. Unset fields filled in by Default,
+// such as an empty OutputFormat or a zero LogLevel, are valid.
+func (c *Config) Validate() error {
+	var errs []error
+	invalid := func(field, format string, args ...any) {
+		errs = append(errs, &ConfigError{Field: field, Msg: fmt.Sprintf(format, args...)})
+	}
+
+	switch c.OutputFormat {
+	case "", OutputFormatTEXT, OutputFormatJSON:
+	default:
+		invalid("OutputFormat", "unknown output format %q", c.OutputFormat)
+	}
+	if len(c.Outputs) == 0 {
+		invalid("Outputs", "no outputs")
+	}
+	for i, output := range c.Outputs {
+		if isNil(output) {
+			invalid(fmt.Sprintf("Outputs[%d]", i), "nil output")
+		}
+	}
+	if c.LogLevel != 0 && !validLevel(c.LogLevel) {
+		invalid("LogLevel", "unknown level %d", int(c.LogLevel))
+	}
+	if c.Caller.Skip < 0 {
+		invalid("Caller.Skip", "negative skip %d", c.Caller.Skip)
+	}
+	if c.Stacktrace.Level != 0 && !validLevel(c.Stacktrace.Level) {
+		invalid("Stacktrace.Level", "unknown level %d", int(c.Stacktrace.Level))
+	}
+	for k := range c.Attrs {
+		if k == "" {
+			invalid("Attrs", "empty key")
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// validLevel reports whether level is one of the defined levels.
+func validLevel(level Level) bool {
+	_, ok := levelNames[level]
+	return ok
+}
+
+// isNil reports whether v is nil or a nil pointer, map, slice, channel or function.
+func isNil(v any) bool {
+	if v == nil {
+		return true
+	}
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Pointer, reflect.Map, reflect.Slice, reflect.Chan, reflect.Func, reflect.Interface:
+		return rv.IsNil()
+	}
+	return false
+}
@@ -0,0 +1,65 @@
+package log
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"os"
+	"testing"
+)
+
+func TestConfig_Validate(t *testing.T) {
+	config := DefaultConfig()
+	if err := config.Validate(); err != nil {
+		t.Errorf("Expected the default config to be valid, got: %v", err)
+	}
+
+	config = Config{Outputs: []io.Writer{os.Stdout}}
+	if err := config.Validate(); err != nil {
+		t.Errorf("Expected a config relying on defaults to be valid, got: %v", err)
+	}
+}
+
+func TestConfig_Validate_Errors(t *testing.T) {
+	var nilBuffer *bytes.Buffer
+	config := Config{
+		OutputFormat: "XML",
+		Outputs:      []io.Writer{os.Stdout, nil, nilBuffer},
+		LogLevel:     Level(42),
+		Caller:       Caller{Skip: -1},
+		Stacktrace:   Stacktrace{Level: Level(-5)},
+		Attrs:        map[string]string{"": "value"},
+	}
+
+	err := config.Validate()
+	if err == nil {
+		t.Fatal("Expected an error for an invalid config")
+	}
+
+	var fields []string
+	for _, err := range err.(interface{ Unwrap() []error }).Unwrap() {
+		var configErr *ConfigError
+		if !errors.As(err, &configErr) {
+			t.Fatalf("Expected a *ConfigError, got %T: %v", err, err)
+		}
+		fields = append(fields, configErr.Field)
+	}
+
+	expected := []string{"OutputFormat", "Outputs[1]", "Outputs[2]", "LogLevel", "Caller.Skip", "Stacktrace.Level", "Attrs"}
+	if len(fields) != len(expected) {
+		t.Fatalf("Expected errors for %v, got %v", expected, fields)
+	}
+	for i := range expected {
+		if fields[i] != expected[i] {
+			t.Errorf("Expected error %d for %s, got %s", i, expected[i], fields[i])
+		}
+	}
+}
+
+func TestConfig_Validate_NoOutputs(t *testing.T) {
+	config := Config{}
+	var configErr *ConfigError
+	if err := config.Validate(); !errors.As(err, &configErr) || configErr.Field != "Outputs" {
+		t.Errorf("Expected an error for Outputs, got: %v", err)
+	}
+}
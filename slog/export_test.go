@@ -0,0 +1,12 @@
+package slog
+
+import (
+	"log/slog"
+
+	"github.com/prakashpandey/golog/log"
+)
+
+// HandlerOf returns the slog.Handler of logger, which must be a *SlogLogger.
+func HandlerOf(logger log.Logger) slog.Handler {
+	return logger.(*SlogLogger).logger.Handler()
+}
@@ -0,0 +1,141 @@
+package slog
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/prakashpandey/golog/log"
+)
+
+// handler is a slog.Handler that writes records to a golog Logger.
+type handler struct {
+	logger log.Logger
+}
+
+// NewHandler returns a slog.Handler that writes the records of a *slog.Logger to
+// logger, so that libraries logging with log/slog write through any golog backend:
+//
+//	slog.New(NewHandler(logger)).Info("msg", "key", "value")
+//
+// Levels map to the closest golog level at or below them, e.g. slog.LevelWarn+1 to
+// log.Warn, where levels above log.Critical are logged at log.Critical, so that a
+// record never panics or exits. Groups of WithGroup are passed to Logger.WithGroup
+// and group attributes are passed as log.Group fields, so that both nest them the
+// same way. The caller of the record's PC is reported as caller. The record's time
+// is ignored, since the backends time entries when they write them.
+func NewHandler(logger log.Logger) slog.Handler {
+	return &handler{logger: logger}
+}
+
+// Enabled implements slog.Handler.
+func (h *handler) Enabled(ctx context.Context, level slog.Level) bool {
+	return h.logger.Enabled(ctx, convertSlogLevel(level))
+}
+
+// Handle implements slog.Handler.
+func (h *handler) Handle(ctx context.Context, r slog.Record) error {
+	fields := make([]log.Field, 0, r.NumAttrs())
+	r.Attrs(func(a slog.Attr) bool {
+		fields = appendAttr(fields, a)
+		return true
+	})
+	keysAndValues := fieldsToKeysAndValues(fields)
+	ctx = log.ContextWithCaller(ctx, r.PC)
+
+	switch convertSlogLevel(r.Level) {
+	case log.Trace:
+		h.logger.Trace(ctx, r.Message, keysAndValues...)
+	case log.Debug:
+		h.logger.Debug(ctx, r.Message, keysAndValues...)
+	case log.Info:
+		h.logger.Info(ctx, r.Message, keysAndValues...)
+	case log.Notice:
+		h.logger.Notice(ctx, r.Message, keysAndValues...)
+	case log.Warn:
+		h.logger.Warn(ctx, r.Message, keysAndValues...)
+	case log.Error:
+		h.logger.Error(ctx, r.Message, keysAndValues...)
+	default:
+		h.logger.Critical(ctx, r.Message, keysAndValues...)
+	}
+	return nil
+}
+
+// WithAttrs implements slog.Handler.
+func (h *handler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	var fields []log.Field
+	for _, a := range attrs {
+		fields = appendAttr(fields, a)
+	}
+	if len(fields) == 0 {
+		return h
+	}
+	return &handler{logger: h.logger.With(fieldsToKeysAndValues(fields)...)}
+}
+
+// WithGroup implements slog.Handler.
+func (h *handler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+	return &handler{logger: h.logger.WithGroup(name)}
+}
+
+// convertSlogLevel converts a slog level to the closest golog level at or below
+// it, up to log.Critical.
+func convertSlogLevel(level slog.Level) log.Level {
+	switch {
+	case level < slog.LevelDebug:
+		return log.Trace
+	case level < slog.LevelInfo:
+		return log.Debug
+	case level < levelNotice:
+		return log.Info
+	case level < slog.LevelWarn:
+		return log.Notice
+	case level < slog.LevelError:
+		return log.Warn
+	case level < levelCritical:
+		return log.Error
+	default:
+		return log.Critical
+	}
+}
+
+// appendAttr appends a converted to a log.Field to fields following the rules of
+// slog.Handler: empty attributes are ignored and so are empty groups, and the
+// attributes of groups with an empty key are inlined. Groups are converted to
+// log.Group fields.
+func appendAttr(fields []log.Field, a slog.Attr) []log.Field {
+	a.Value = a.Value.Resolve()
+	if a.Equal(slog.Attr{}) {
+		return fields
+	}
+	if a.Value.Kind() != slog.KindGroup {
+		return append(fields, log.Field{Key: a.Key, Value: a.Value.Any()})
+	}
+	if a.Key == "" {
+		for _, ga := range a.Value.Group() {
+			fields = appendAttr(fields, ga)
+		}
+		return fields
+	}
+	var group []log.Field
+	for _, ga := range a.Value.Group() {
+		group = appendAttr(group, ga)
+	}
+	if len(group) == 0 {
+		return fields
+	}
+	return append(fields, log.Group(a.Key, group...))
+}
+
+// fieldsToKeysAndValues returns fields as keysAndValues, in which each log.Field
+// stands for its key and value.
+func fieldsToKeysAndValues(fields []log.Field) []any {
+	keysAndValues := make([]any, len(fields))
+	for i, f := range fields {
+		keysAndValues[i] = f
+	}
+	return keysAndValues
+}
@@ -0,0 +1,131 @@
+package slog_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	stdslog "log/slog"
+	"runtime"
+	"strings"
+	"testing"
+	"testing/slogtest"
+
+	"github.com/prakashpandey/golog/log"
+	"github.com/prakashpandey/golog/slog"
+	"github.com/prakashpandey/golog/zap"
+)
+
+func TestNewHandler(t *testing.T) {
+	var buf strings.Builder
+	config := log.Config{
+		Outputs:      []io.Writer{&buf},
+		OutputFormat: log.OutputFormatJSON,
+		LogLevel:     log.Info,
+	}
+	logger := stdslog.New(slog.NewHandler(slog.NewSlogLogger(config)))
+
+	logger.Debug("Debug message")
+	if buf.Len() != 0 {
+		t.Fatalf("Expected debug records to be disabled, got: %s", buf.String())
+	}
+
+	logger.With("service", "api").WithGroup("req").Warn("Handled",
+		"id", 7, stdslog.Group("user", "name", "alice"), stdslog.Group("", "inline", true), stdslog.Attr{})
+
+	var entry map[string]any
+	if err := json.Unmarshal([]byte(buf.String()), &entry); err != nil {
+		t.Fatalf("Invalid JSON output %q: %v", buf.String(), err)
+	}
+	if entry["level"] != "WARN" || entry["msg"] != "Handled" || entry["service"] != "api" {
+		t.Errorf("Unexpected entry: %v", entry)
+	}
+	req, _ := entry["req"].(map[string]any)
+	user, _ := req["user"].(map[string]any)
+	if req["id"] != float64(7) || user["name"] != "alice" || req["inline"] != true {
+		t.Errorf("Unexpected group: %v", entry["req"])
+	}
+	if _, ok := req[""]; ok {
+		t.Errorf("Expected the empty attribute to be ignored, got %v", req)
+	}
+}
+
+func TestNewHandler_Levels(t *testing.T) {
+	var buf strings.Builder
+	config := log.Config{
+		Outputs:      []io.Writer{&buf},
+		OutputFormat: log.OutputFormatJSON,
+		LogLevel:     log.Trace,
+	}
+	logger := stdslog.New(slog.NewHandler(slog.NewSlogLogger(config)))
+	ctx := context.Background()
+
+	tests := []struct {
+		level    stdslog.Level
+		expected string
+	}{
+		{stdslog.LevelDebug - 4, "TRACE"},
+		{stdslog.LevelDebug, "DEBUG"},
+		{stdslog.LevelInfo + 1, "INFO"},
+		{stdslog.LevelInfo + 2, "NOTICE"},
+		{stdslog.LevelWarn, "WARN"},
+		{stdslog.LevelError, "ERROR"},
+		{stdslog.LevelError + 100, "CRITICAL"},
+	}
+	for _, tt := range tests {
+		buf.Reset()
+		logger.Log(ctx, tt.level, "message")
+		if !strings.Contains(buf.String(), `"level":"`+tt.expected+`"`) {
+			t.Errorf("Expected slog level %v to be logged as %s, got: %s", tt.level, tt.expected, buf.String())
+		}
+	}
+}
+
+func TestNewHandler_Caller(t *testing.T) {
+	var buf bytes.Buffer
+	config := log.Config{
+		Outputs:      []io.Writer{&buf},
+		OutputFormat: log.OutputFormatJSON,
+		Caller:       log.Caller{Enabled: true},
+	}
+	// The zap backend shows that records flow into any golog backend.
+	logger := stdslog.New(slog.NewHandler(zap.NewZapLogger(config)))
+
+	logger.Info("Zap message")
+	_, file, line, _ := runtime.Caller(0)
+	if expected := fmt.Sprintf("%s:%d", file, line-1); !strings.Contains(buf.String(), expected) {
+		t.Errorf("Expected caller %q in log output, got: %s", expected, buf.String())
+	}
+}
+
+func TestNewHandler_Slogtest(t *testing.T) {
+	backends := map[string]func(log.Config) log.Logger{
+		"slog": slog.NewSlogLogger,
+		"zap":  zap.NewZapLogger,
+	}
+	for name, newLogger := range backends {
+		t.Run(name, func(t *testing.T) {
+			var buf bytes.Buffer
+			newHandler := func(t *testing.T) stdslog.Handler {
+				if strings.HasSuffix(t.Name(), "/zero-time") {
+					t.Skip("The backends time entries when they write them")
+				}
+				buf.Reset()
+				return slog.NewHandler(newLogger(log.Config{Outputs: []io.Writer{&buf}, OutputFormat: log.OutputFormatJSON}))
+			}
+			result := func(t *testing.T) map[string]any {
+				var entry map[string]any
+				if err := json.Unmarshal(buf.Bytes(), &entry); err != nil {
+					t.Fatalf("Invalid JSON output %q: %v", buf.String(), err)
+				}
+				if ts, ok := entry["ts"]; ok { // The time key of the zap backend.
+					delete(entry, "ts")
+					entry[stdslog.TimeKey] = ts
+				}
+				return entry
+			}
+			slogtest.Run(t, newHandler, result)
+		})
+	}
+}
@@ -0,0 +1,7 @@
+//go:build !race
+
+package slog_test
+
+// raceEnabled reports whether the race detector is enabled, which makes sync.Pool
+// drop items and allocation counts unreliable.
+const raceEnabled = false
@@ -2,9 +2,13 @@ package slog
 
 import (
 	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
 	"io"
 	"log/slog"
-	"os"
+	"slices"
+	"sync"
 
 	"github.com/prakashpandey/golog/caller"
 	"github.com/prakashpandey/golog/log"
@@ -12,35 +16,140 @@ import (
 
 // SlogLogger is a concrete implementation of the Logger interface using slog.
 type SlogLogger struct {
-	logger *slog.Logger
+	logger *slog.Logger // Logger without the groups, which logs the metadata of entries at the top level.
+	groups []group      // Groups opened with WithGroup, outermost first.
+	name   string       // Dot-separated logger name, empty for the root logger.
+	skip   int          // Additional stack frames to skip, see WithCallerSkip.
 	log.Config
 }
 
+// group is a group opened with WithGroup and the attributes added to it with With.
+type group struct {
+	name  string
+	attrs []slog.Attr
+}
+
+// Custom slog levels for the golog levels that slog does not define.
+const (
+	levelTrace    = slog.LevelDebug - 4
+	levelNotice   = slog.LevelInfo + 2
+	levelCritical = slog.LevelError + 4
+	levelPanic    = slog.LevelError + 8
+	levelFatal    = slog.LevelError + 12
+)
+
+// levelNames holds the names of the custom slog levels.
+var levelNames = map[slog.Level]string{
+	levelTrace:    "TRACE",
+	levelNotice:   "NOTICE",
+	levelCritical: "CRITICAL",
+	levelPanic:    "PANIC",
+	levelFatal:    "FATAL",
+}
+
 // Convert the custom LogLevel to slog's LogLevel.
 func convertLogLevel(level log.Level) slog.Level {
 	switch level {
+	case log.Trace:
+		return levelTrace
 	case log.Debug:
 		return slog.LevelDebug
 	case log.Info:
 		return slog.LevelInfo
+	case log.Notice:
+		return levelNotice
 	case log.Warn:
 		return slog.LevelWarn
 	case log.Error:
 		return slog.LevelError
+	case log.Critical:
+		return levelCritical
+	case log.Panic:
+		return levelPanic
+	case log.Fatal:
+		return levelFatal
 	default:
 		return slog.LevelInfo
 	}
 }
 
+// nativeLevel converts a minimum log level to a slog.Level for a slog.Handler.
+// An unset level enables every level and Off disables all of them.
+func nativeLevel(level log.Level) slog.Level {
+	switch {
+	case level == 0:
+		return levelTrace
+	case level >= log.Off:
+		return levelFatal + 1
+	default:
+		return convertLogLevel(level)
+	}
+}
+
+// leveler is a slog.Leveler that reads the lowest level of a log.Config on every
+// call, see log.Config.MinLevel, so that the handler follows changes of
+// config.Level and config.NamedLevels.
+type leveler struct {
+	config log.Config
+}
+
+func (l *leveler) Level() slog.Level {
+	return nativeLevel(l.config.MinLevel())
+}
+
+// replaceLevel names the custom slog levels in the output instead of e.g. "ERROR+4".
+// Built-in levels are replaced by their names too, since the JSON handler would
+// otherwise encode the slog.Level with encoding/json, which allocates.
+func replaceLevel(groups []string, a slog.Attr) slog.Attr {
+	if len(groups) == 0 && a.Key == slog.LevelKey {
+		if level, ok := a.Value.Any().(slog.Level); ok {
+			if name, ok := levelNames[level]; ok {
+				a.Value = slog.StringValue(name)
+			} else {
+				a.Value = slog.StringValue(level.String())
+			}
+		}
+	}
+	return a
+}
+
 // NewSlogLogger initializes the SlogLogger with the given config.
+// The configuration is not validated, see New.
 func NewSlogLogger(config log.Config) log.Logger {
 	config.Sanitize()
 	config.Default()
+	return newSlogLogger(config)
+}
+
+// BackendName is the name under which this package registers its backend with
+// log.RegisterBackend, so that log.New(BackendName, config) builds a SlogLogger.
+const BackendName = "slog"
+
+func init() {
+	log.RegisterBackend(BackendName, New)
+}
+
+// New initializes the SlogLogger with the given config.
+// It returns the errors reported by config.Validate instead of a logger for an invalid configuration.
+func New(config log.Config) (log.Logger, error) {
+	config.Sanitize()
+	config.Default()
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+	return newSlogLogger(config), nil
+}
+
+// newSlogLogger initializes the SlogLogger for a sanitized config with defaults applied.
+func newSlogLogger(config log.Config) log.Logger {
 	multiWriter := io.MultiWriter(config.Outputs...)
 
-	// Create HandlerOptions with the desired log level.
+	// The handler enforces the lowest level of the logger and its named loggers, so
+	// that a change of config.Level takes effect in slog too. SlogLogger enforces the
+	// exact level of each named logger.
 	handlerOptions := &slog.HandlerOptions{
-		Level: convertLogLevel(config.LogLevel),
+		Level:       &leveler{config},
+		ReplaceAttr: replaceLevel,
 	}
 
 	// Define handler based on log format.
@@ -67,40 +176,347 @@ func NewSlogLogger(config log.Config) log.Logger {
 	}
 }
 
-// stacktrace returns the keys and values with caller and stack trace information.
-// It appends caller and stack trace information to the keys and values if enabled.
-// Caller information is appended only if enabled.
-// Stack trace information is appended only if enabled and the log level is greater than or equal to the stack trace level.
-func (l *SlogLogger) stacktrace(level log.Level, keysAndValues []any) []any {
-	return caller.AddStacktrace(level, l.Config, keysAndValues)
+// attrsPool holds buffers for the attributes of entries, so that logging does not
+// allocate them. slog copies the attributes before LogAttrs returns.
+var attrsPool = sync.Pool{
+	New: func() any {
+		attrs := make([]slog.Attr, 0, 16)
+		return &attrs
+	},
+}
+
+// appendAttrs appends keysAndValues converted to slog.Attrs to attrs, see
+// log.NextField. A slog.Attr in the position of a key is used as is. Typed
+// log.Fields are converted without reflection and errors are logged as
+// log.ErrorDetails groups. It returns the index of the first malformed element of
+// keysAndValues, or -1.
+func appendAttrs(attrs []slog.Attr, keysAndValues []any) ([]slog.Attr, int) {
+	bad := -1
+	for i := 0; i < len(keysAndValues); {
+		if a, ok := keysAndValues[i].(slog.Attr); ok {
+			attrs = append(attrs, a)
+			i++
+			continue
+		}
+		f, next, ok := log.NextField(keysAndValues, i)
+		if !ok && bad < 0 {
+			bad = i
+		}
+		attrs = append(attrs, fieldAttr(f))
+		i = next
+	}
+	return attrs, bad
+}
+
+// fieldAttr converts a log.Field to a slog.Attr.
+func fieldAttr(f log.Field) slog.Attr {
+	switch f.Type {
+	case log.StringType:
+		return slog.String(f.Key, f.Str)
+	case log.Int64Type:
+		return slog.Int64(f.Key, f.Int)
+	case log.Float64Type:
+		return slog.Float64(f.Key, f.Float64())
+	case log.BoolType:
+		return slog.Bool(f.Key, f.Bool())
+	case log.DurationType:
+		return slog.Duration(f.Key, f.Duration())
+	case log.TimeType:
+		return slog.Time(f.Key, f.Time())
+	case log.BytesType:
+		b, _ := f.Value.([]byte)
+		return slog.String(f.Key, base64.StdEncoding.EncodeToString(b))
+	case log.ObjectType:
+		return slog.Any(f.Key, f.Value)
+	case log.GroupType:
+		group := f.Group()
+		attrs := make([]slog.Attr, 0, len(group))
+		for _, gf := range group {
+			attrs = append(attrs, fieldAttr(gf))
+		}
+		return slog.Attr{Key: f.Key, Value: slog.GroupValue(attrs...)}
+	default:
+		return anyAttr(f.Key, f.Value)
+	}
+}
+
+// anyAttr converts a key and a value of any type to a slog.Attr.
+func anyAttr(key string, value any) slog.Attr {
+	if err, ok := value.(error); ok && err != nil {
+		if log.IsNilError(err) {
+			return slog.String(key, "<nil>")
+		}
+		return slog.Any(key, errorValue{err})
+	}
+	return slog.Any(key, value)
+}
+
+// errorValue is a slog.LogValuer logging the log.ErrorDetails of an error as a group.
+type errorValue struct {
+	err error
+}
+
+// LogValue implements slog.LogValuer.
+func (v errorValue) LogValue() slog.Value {
+	d := log.NewErrorDetails(v.err)
+	attrs := []slog.Attr{
+		slog.String("msg", d.Msg),
+		slog.String("type", d.Type),
+	}
+	if len(d.Chain) > 0 {
+		attrs = append(attrs, slog.Any("chain", errorChain(d.Chain)))
+	}
+	if d.Detail != "" {
+		attrs = append(attrs, slog.String("detail", d.Detail))
+	}
+	return slog.GroupValue(attrs...)
+}
+
+// errorChain is log.ErrorDetails.Chain, logged as an array in JSON format and as
+// e.g. "[EOF (*errors.errorString)]" in TEXT format.
+type errorChain []log.ErrorCause
+
+// MarshalJSON implements json.Marshaler, used by slog's JSON handler.
+func (c errorChain) MarshalJSON() ([]byte, error) {
+	return json.Marshal([]log.ErrorCause(c))
+}
+
+// MarshalText implements encoding.TextMarshaler, used by slog's TEXT handler.
+func (c errorChain) MarshalText() ([]byte, error) {
+	b := []byte{'['}
+	for i, cause := range c {
+		if i > 0 {
+			b = append(b, ", "...)
+		}
+		b = fmt.Appendf(b, "%s (%s)", cause.Msg, cause.Type)
+	}
+	return append(b, ']'), nil
+}
+
+// callerSkip is the number of stack frames between metadata and the user's call site:
+// metadata itself, log and the exported logging method calling it.
+const callerSkip = 3
+
+// metadata returns the logger name, caller and stack trace information as keys and
+// values, which are logged at the top level of entries rather than in the groups of
+// the logger, like the zap backend does.
+// The logger name is returned for named loggers and Config.LiveAttrs are appended,
+// like the static Config.Attrs of the handler.
+// Caller information is returned only if enabled.
+// Stack trace information is returned only if enabled and the log level is greater than or equal to the stack trace level.
+func (l *SlogLogger) metadata(ctx context.Context, level log.Level) []any {
+	config := l.Config
+	config.Caller.Skip += callerSkip + l.skip
+	keysAndValues := l.liveAttrs(caller.AddStacktraceContext(ctx, level, config, nil))
+	if l.name != "" {
+		keysAndValues = append([]any{l.NameField, l.name}, keysAndValues...)
+	}
+	return keysAndValues
+}
+
+// liveAttrs returns keysAndValues with Config.LiveAttrs appended.
+func (l *SlogLogger) liveAttrs(keysAndValues []any) []any {
+	if l.LiveAttrs == nil {
+		return keysAndValues
+	}
+	attrs := l.LiveAttrs.KeysAndValues()
+	if len(keysAndValues) == 0 {
+		return attrs
+	}
+	return append(keysAndValues, attrs...)
+}
+
+// nest returns attrs nested in the groups of the logger, together with the
+// attributes added to each group with With.
+func (l *SlogLogger) nest(attrs []slog.Attr) slog.Attr {
+	for i := len(l.groups) - 1; i >= 0; i-- {
+		g := l.groups[i]
+		nested := make([]slog.Attr, 0, len(g.attrs)+len(attrs))
+		nested = append(append(nested, g.attrs...), attrs...)
+		attrs = []slog.Attr{{Key: g.name, Value: slog.GroupValue(nested...)}}
+	}
+	return attrs[0]
+}
+
+// log writes an entry at level with keysAndValues and fields. Callers check whether
+// level is enabled.
+func (l *SlogLogger) log(ctx context.Context, level log.Level, msg string, keysAndValues []any, fields []log.Field) {
+	buf := attrsPool.Get().(*[]slog.Attr)
+	attrs, _ := appendAttrs((*buf)[:0], l.metadata(ctx, level))
+	// Fields stored in ctx with log.ContextWithFields are logged at the top level too,
+	// so that e.g. a request ID has the same key in every entry. They are converted
+	// on their own, so that a malformed keysAndValues cannot shift them.
+	attrs, _ = appendAttrs(attrs, log.FieldsFromContext(ctx))
+	n := len(attrs)
+	attrs, bad := appendAttrs(attrs, keysAndValues)
+	if bad >= 0 {
+		log.ReportMalformed(ctx, l.Config, l.Caller.Skip+l.skip+2, keysAndValues[bad]) // log, e.g. Info
+	}
+	for _, f := range fields {
+		attrs = append(attrs, fieldAttr(f))
+	}
+	if len(l.groups) > 0 {
+		attrs = append(attrs[:n], l.nest(attrs[n:]))
+	}
+	l.logger.LogAttrs(ctx, convertLogLevel(level), msg, attrs...)
+	clear(attrs[:cap(attrs)]) // Do not keep the values alive, including the attributes moved into groups.
+	*buf = attrs[:0]
+	attrsPool.Put(buf)
+}
+
+func (l *SlogLogger) Trace(ctx context.Context, msg string, keysAndValues ...any) {
+	if l.Enabled(ctx, log.Trace) {
+		l.log(ctx, log.Trace, msg, keysAndValues, nil)
+	}
 }
 
 func (l *SlogLogger) Debug(ctx context.Context, msg string, keysAndValues ...any) {
-	if l.LogLevel <= log.Debug {
-		l.logger.DebugContext(ctx, msg, l.stacktrace(log.Debug, keysAndValues)...)
+	if l.Enabled(ctx, log.Debug) {
+		l.log(ctx, log.Debug, msg, keysAndValues, nil)
 	}
 }
 
 func (l *SlogLogger) Info(ctx context.Context, msg string, keysAndValues ...any) {
-	if l.LogLevel <= log.Info {
-		l.logger.InfoContext(ctx, msg, l.stacktrace(log.Info, keysAndValues)...)
+	if l.Enabled(ctx, log.Info) {
+		l.log(ctx, log.Info, msg, keysAndValues, nil)
+	}
+}
+
+func (l *SlogLogger) Notice(ctx context.Context, msg string, keysAndValues ...any) {
+	if l.Enabled(ctx, log.Notice) {
+		l.log(ctx, log.Notice, msg, keysAndValues, nil)
 	}
 }
 
 func (l *SlogLogger) Warn(ctx context.Context, msg string, keysAndValues ...any) {
-	if l.LogLevel <= log.Warn {
-		l.logger.WarnContext(ctx, msg, l.stacktrace(log.Warn, keysAndValues)...)
+	if l.Enabled(ctx, log.Warn) {
+		l.log(ctx, log.Warn, msg, keysAndValues, nil)
 	}
 }
 
 func (l *SlogLogger) Error(ctx context.Context, msg string, keysAndValues ...any) {
-	if l.LogLevel <= log.Error {
-		l.logger.ErrorContext(ctx, msg, l.stacktrace(log.Error, keysAndValues)...)
+	if l.Enabled(ctx, log.Error) {
+		l.log(ctx, log.Error, msg, keysAndValues, nil)
+	}
+}
+
+func (l *SlogLogger) Critical(ctx context.Context, msg string, keysAndValues ...any) {
+	if l.Enabled(ctx, log.Critical) {
+		l.log(ctx, log.Critical, msg, keysAndValues, nil)
+	}
+}
+
+// With returns a child logger that carries keysAndValues on every entry.
+func (l *SlogLogger) With(keysAndValues ...any) log.Logger {
+	attrs, bad := appendAttrs(nil, keysAndValues)
+	if bad >= 0 {
+		log.ReportMalformed(context.Background(), l.Config, 1, keysAndValues[bad])
+	}
+	if len(l.groups) > 0 {
+		groups := slices.Clone(l.groups)
+		last := &groups[len(groups)-1]
+		last.attrs = append(slices.Clip(last.attrs), attrs...)
+		return &SlogLogger{
+			logger: l.logger,
+			groups: groups,
+			name:   l.name,
+			skip:   l.skip,
+			Config: l.Config,
+		}
+	}
+	return &SlogLogger{
+		logger: slog.New(l.logger.Handler().WithAttrs(attrs)),
+		name:   l.name,
+		skip:   l.skip,
+		Config: l.Config,
 	}
 }
 
-// Fatal always logs to ErrorContext irrespective of log level and calls os.Exit(1).
+// WithGroup returns a child logger that nests subsequent fields under name.
+// Caller and stack trace information stay at the top level.
+func (l *SlogLogger) WithGroup(name string) log.Logger {
+	if name == "" {
+		return l
+	}
+	return &SlogLogger{
+		logger: l.logger,
+		groups: append(slices.Clip(l.groups), group{name: name}),
+		name:   l.name,
+		skip:   l.skip,
+		Config: l.Config,
+	}
+}
+
+// Named returns a child logger named after its parent's name joined with name.
+func (l *SlogLogger) Named(name string) log.Logger {
+	if name == "" {
+		return l
+	}
+	return &SlogLogger{
+		logger: l.logger,
+		groups: l.groups,
+		name:   log.JoinName(l.name, name),
+		skip:   l.skip,
+		Config: l.Config,
+	}
+}
+
+// Enabled reports whether the logger writes entries at level.
+func (l *SlogLogger) Enabled(ctx context.Context, level log.Level) bool {
+	return l.LevelFor(l.name) <= level
+}
+
+// WithCallerSkip returns a child logger that skips skip additional stack frames.
+func (l *SlogLogger) WithCallerSkip(skip int) log.Logger {
+	return &SlogLogger{
+		logger: l.logger,
+		groups: l.groups,
+		name:   l.name,
+		skip:   l.skip + skip,
+		Config: l.Config,
+	}
+}
+
+// Panic logs at Panic level if enabled, flushes the outputs and then panics with msg.
+func (l *SlogLogger) Panic(ctx context.Context, msg string, keysAndValues ...any) {
+	if l.Enabled(ctx, log.Panic) {
+		l.log(ctx, log.Panic, msg, keysAndValues, nil)
+	}
+	_ = l.Sync()
+	panic(msg)
+}
+
+// LogFields logs fields at level without converting them to interfaces, see
+// log.FieldLogger.
+func (l *SlogLogger) LogFields(ctx context.Context, level log.Level, msg string, fields ...log.Field) {
+	if l.Enabled(ctx, level) {
+		l.log(ctx, level, msg, nil, fields)
+	}
+	switch level {
+	case log.Panic:
+		_ = l.Sync()
+		panic(msg)
+	case log.Fatal:
+		_ = l.Sync()
+		log.Exit(l.ExitFunc, 1)
+	}
+}
+
+// Fatal logs at Fatal level if enabled, flushes the outputs and then calls log.Exit with Config.ExitFunc.
 func (l *SlogLogger) Fatal(ctx context.Context, msg string, keysAndValues ...any) {
-	l.logger.ErrorContext(ctx, msg, l.stacktrace(log.Error, keysAndValues)...)
-	os.Exit(1)
+	if l.Enabled(ctx, log.Fatal) {
+		l.log(ctx, log.Fatal, msg, keysAndValues, nil)
+	}
+	_ = l.Sync()
+	log.Exit(l.ExitFunc, 1)
+}
+
+// Sync flushes the outputs, see log.SyncOutputs.
+func (l *SlogLogger) Sync() error {
+	return log.SyncOutputs(l.Outputs)
+}
+
+// Close flushes and closes the outputs, see log.CloseOutputs.
+func (l *SlogLogger) Close() error {
+	return log.CloseOutputs(l.Outputs)
 }
@@ -1,10 +1,21 @@
 package slog_test
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
 	"io"
+	stdlog "log"
+	stdslog "log/slog"
+	"os"
+	"path/filepath"
+	"reflect"
+	"runtime"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/prakashpandey/golog/log"
 	"github.com/prakashpandey/golog/slog"
@@ -73,3 +84,741 @@ func TestSlogLogger_DebugLevel(t *testing.T) {
 		t.Errorf("Expected 'Error message' in log output, but it was not logged")
 	}
 }
+
+// TestSlogLogger_With tests that fields bound with With are carried by every entry.
+func TestSlogLogger_With(t *testing.T) {
+	var buf strings.Builder
+	config := log.Config{
+		Outputs:      []io.Writer{&buf},
+		OutputFormat: log.OutputFormatJSON,
+		LogLevel:     log.Info,
+	}
+
+	logger := slog.NewSlogLogger(config)
+	child := logger.With("request_id", "abc123")
+
+	child.Info(context.Background(), "Child message")
+	logger.Info(context.Background(), "Parent message")
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("Expected 2 log lines, got %d: %s", len(lines), buf.String())
+	}
+	if !strings.Contains(lines[0], `"request_id":"abc123"`) {
+		t.Errorf("Expected child entry to contain request_id, got: %s", lines[0])
+	}
+	if strings.Contains(lines[1], "request_id") {
+		t.Errorf("Expected parent entry to not contain request_id, got: %s", lines[1])
+	}
+}
+
+// TestSlogLogger_WithGroup tests that grouped fields are nested in JSON and dotted in TEXT.
+func TestSlogLogger_WithGroup(t *testing.T) {
+	tests := []struct {
+		format   log.OutputFormat
+		expected string
+	}{
+		{log.OutputFormatJSON, `"http":{"method":"GET","status":200}`},
+		{log.OutputFormatTEXT, "http.method=GET http.status=200"},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.format), func(t *testing.T) {
+			var buf strings.Builder
+			config := log.Config{
+				Outputs:      []io.Writer{&buf},
+				OutputFormat: tt.format,
+				LogLevel:     log.Info,
+			}
+
+			logger := slog.NewSlogLogger(config).WithGroup("http").With("method", "GET")
+			logger.Info(context.Background(), "Request served", "status", 200)
+
+			if !strings.Contains(buf.String(), tt.expected) {
+				t.Errorf("Expected %q in log output, got: %s", tt.expected, buf.String())
+			}
+		})
+	}
+}
+
+// TestSlogLogger_WithGroup_Metadata tests that caller and stack trace information stay at the
+// top level of grouped entries.
+func TestSlogLogger_WithGroup_Metadata(t *testing.T) {
+	for _, format := range []log.OutputFormat{log.OutputFormatJSON, log.OutputFormatTEXT} {
+		var buf strings.Builder
+		config := log.Config{
+			Outputs:      []io.Writer{&buf},
+			OutputFormat: format,
+			Caller:       log.Caller{Enabled: true},
+			Stacktrace:   log.Stacktrace{Enabled: true, Level: log.Info},
+		}
+
+		slog.NewSlogLogger(config).WithGroup("http").With("method", "GET").Info(context.Background(), "Request served", "status", 200)
+
+		out := buf.String()
+		if format == log.OutputFormatJSON {
+			var entry map[string]any
+			if err := json.Unmarshal([]byte(out), &entry); err != nil {
+				t.Fatalf("Failed to parse log output %s: %v", out, err)
+			}
+			group, _ := entry["http"].(map[string]any)
+			if entry["caller"] == nil || entry["stacktrace"] == nil || group["caller"] != nil || group["stacktrace"] != nil {
+				t.Errorf("Expected caller and stack trace at the top level, got: %s", out)
+			}
+			if group["method"] != "GET" || group["status"] != float64(200) {
+				t.Errorf("Expected the fields in the group, got: %s", out)
+			}
+			continue
+		}
+		if !strings.Contains(out, "caller") || strings.Contains(out, "http.caller") || strings.Contains(out, "http.stacktrace") {
+			t.Errorf("Expected caller and stack trace at the top level in %s output, got: %s", format, out)
+		}
+	}
+}
+
+// TestSlogLogger_Named_WithGroup tests that the logger name stays at the top level of
+// grouped entries.
+func TestSlogLogger_Named_WithGroup(t *testing.T) {
+	var buf strings.Builder
+	logger := slog.NewSlogLogger(log.Config{Outputs: []io.Writer{&buf}, OutputFormat: log.OutputFormatJSON})
+
+	logger.Named("db").WithGroup("http").Info(context.Background(), "Request served", "status", 200)
+
+	var entry map[string]any
+	if err := json.Unmarshal([]byte(buf.String()), &entry); err != nil {
+		t.Fatalf("Failed to parse log output %s: %v", buf.String(), err)
+	}
+	if group, _ := entry["http"].(map[string]any); entry["logger"] != "db" || group["logger"] != nil {
+		t.Errorf("Expected the logger name at the top level, got: %s", buf.String())
+	}
+}
+
+// TestSlogLogger_Named tests that named loggers emit their name and honor per-name levels.
+func TestSlogLogger_Named(t *testing.T) {
+	var buf strings.Builder
+	config := log.Config{
+		Outputs:      []io.Writer{&buf},
+		OutputFormat: log.OutputFormatJSON,
+		LogLevel:     log.Warn,
+		NamedLevels:  log.NewNamedLevels(map[string]log.Level{"db": log.Info}),
+	}
+
+	logger := slog.NewSlogLogger(config)
+	logger.Named("db").Named("pool").Info(context.Background(), "Pool message")
+	logger.Named("http").Info(context.Background(), "HTTP message")
+
+	if !strings.Contains(buf.String(), `"logger":"db.pool"`) {
+		t.Errorf("Expected logger name db.pool in log output, got: %s", buf.String())
+	}
+	if !strings.Contains(buf.String(), "Pool message") {
+		t.Errorf("Expected 'Pool message' in log output, but it was not logged")
+	}
+	if strings.Contains(buf.String(), "HTTP message") {
+		t.Errorf("Expected 'HTTP message' to be filtered out, but it was logged")
+	}
+}
+
+// TestSlogLogger_ContextFields tests that fields stored in the context are logged.
+func TestSlogLogger_ContextFields(t *testing.T) {
+	var buf strings.Builder
+	config := log.Config{
+		Outputs:      []io.Writer{&buf},
+		OutputFormat: log.OutputFormatJSON,
+		LogLevel:     log.Info,
+	}
+
+	logger := slog.NewSlogLogger(config)
+	ctx := log.ContextWithFields(context.Background(), "request_id", "abc123")
+	logger.Info(ctx, "Request message", "key", "value")
+
+	if !strings.Contains(buf.String(), `"request_id":"abc123","key":"value"`) {
+		t.Errorf("Expected context fields in log output, got: %s", buf.String())
+	}
+}
+
+// TestSlogLogger_AtomicLevel tests that the level can be changed at runtime.
+func TestSlogLogger_AtomicLevel(t *testing.T) {
+	var buf strings.Builder
+	level := log.NewAtomicLevel(log.Error)
+	config := log.Config{
+		Outputs:      []io.Writer{&buf},
+		OutputFormat: log.OutputFormatJSON,
+		Level:        level,
+	}
+
+	logger := slog.NewSlogLogger(config)
+	logger.Warn(context.Background(), "Before change")
+	level.SetLevel(log.Warn)
+	logger.Warn(context.Background(), "After change")
+
+	if strings.Contains(buf.String(), "Before change") {
+		t.Errorf("Expected 'Before change' to be filtered out, but it was logged")
+	}
+	if !strings.Contains(buf.String(), "After change") {
+		t.Errorf("Expected 'After change' in log output, but it was not logged")
+	}
+}
+
+func TestSlogLogger_AtomicLevel_Handler(t *testing.T) {
+	level := log.NewAtomicLevel(log.Error)
+	named := log.NewNamedLevels(nil)
+	config := log.Config{
+		Outputs:     []io.Writer{io.Discard},
+		Level:       level,
+		NamedLevels: named,
+	}
+
+	ctx := context.Background()
+	handler := slog.HandlerOf(slog.NewSlogLogger(config))
+	if handler.Enabled(ctx, stdslog.LevelWarn) {
+		t.Errorf("Expected the handler to disable WARN at level error")
+	}
+	level.SetLevel(log.Notice)
+	if handler.Enabled(ctx, stdslog.LevelInfo) || !handler.Enabled(ctx, stdslog.LevelWarn) {
+		t.Errorf("Expected the handler to enable notice and above at level notice")
+	}
+	named.SetLevel("db", log.Debug)
+	if !handler.Enabled(ctx, stdslog.LevelDebug) || handler.Enabled(ctx, stdslog.LevelDebug-4) {
+		t.Errorf("Expected the handler to enable debug and above for a named logger at level debug")
+	}
+	named.Unset("db")
+	level.SetLevel(log.Off)
+	if handler.Enabled(ctx, stdslog.LevelError+12) {
+		t.Errorf("Expected the handler to disable every level at level off")
+	}
+}
+
+// TestSlogLogger_Caller tests that caller information points at the user's call site,
+// including calls through log.Sugar.
+func TestSlogLogger_Caller(t *testing.T) {
+	var buf strings.Builder
+	config := log.Config{
+		Outputs:      []io.Writer{&buf},
+		OutputFormat: log.OutputFormatJSON,
+		LogLevel:     log.Info,
+		Caller:       log.Caller{Enabled: true},
+	}
+
+	logger := slog.NewSlogLogger(config)
+	ctx := context.Background()
+
+	logger.Info(ctx, "Direct message")
+	_, file, line, _ := runtime.Caller(0)
+	if expected := fmt.Sprintf("%s:%d", file, line-1); !strings.Contains(buf.String(), expected) {
+		t.Errorf("Expected caller %q in log output, got: %s", expected, buf.String())
+	}
+
+	buf.Reset()
+	log.NewSugar(logger).Infof(ctx, "Sugared %s", "message")
+	_, file, line, _ = runtime.Caller(0)
+	if expected := fmt.Sprintf("%s:%d", file, line-1); !strings.Contains(buf.String(), expected) {
+		t.Errorf("Expected caller %q in log output, got: %s", expected, buf.String())
+	}
+}
+
+// TestSlogLogger_ExtendedLevels tests the level names of the levels slog does not define.
+func TestSlogLogger_ExtendedLevels(t *testing.T) {
+	var buf strings.Builder
+	config := log.Config{
+		Outputs:      []io.Writer{&buf},
+		OutputFormat: log.OutputFormatJSON,
+		Level:        log.NewAtomicLevel(log.Trace),
+	}
+
+	logger := slog.NewSlogLogger(config)
+	ctx := context.Background()
+	logger.Trace(ctx, "Trace message")
+	logger.Notice(ctx, "Notice message")
+	logger.Critical(ctx, "Critical message")
+
+	for _, expected := range []string{`"level":"TRACE"`, `"level":"NOTICE"`, `"level":"CRITICAL"`} {
+		if !strings.Contains(buf.String(), expected) {
+			t.Errorf("Expected %s in log output, got: %s", expected, buf.String())
+		}
+	}
+}
+
+// TestSlogLogger_Panic tests that Panic logs and then panics with the message.
+func TestSlogLogger_Panic(t *testing.T) {
+	var buf strings.Builder
+	config := log.Config{
+		Outputs:      []io.Writer{&buf},
+		OutputFormat: log.OutputFormatJSON,
+		LogLevel:     log.Info,
+	}
+
+	logger := slog.NewSlogLogger(config)
+	defer func() {
+		if r := recover(); r != "Panic message" {
+			t.Errorf("Expected panic with 'Panic message', got: %v", r)
+		}
+		if !strings.Contains(buf.String(), `"level":"PANIC"`) {
+			t.Errorf("Expected panic entry in log output, got: %s", buf.String())
+		}
+	}()
+	logger.Panic(context.Background(), "Panic message")
+}
+
+// TestSlogLogger_Off tests that the Off threshold disables logging.
+func TestSlogLogger_Off(t *testing.T) {
+	var buf strings.Builder
+	config := log.Config{
+		Outputs:      []io.Writer{&buf},
+		OutputFormat: log.OutputFormatJSON,
+		LogLevel:     log.Off,
+	}
+
+	logger := slog.NewSlogLogger(config)
+	logger.Critical(context.Background(), "Critical message")
+
+	if buf.Len() != 0 {
+		t.Errorf("Expected no log output, got: %s", buf.String())
+	}
+}
+
+// TestSlogLogger_LiveAttrs tests that attributes replaced at runtime are logged.
+func TestSlogLogger_LiveAttrs(t *testing.T) {
+	var buf strings.Builder
+	attrs := log.NewAtomicAttrs(map[string]string{"version": "1"})
+	config := log.Config{
+		Outputs:      []io.Writer{&buf},
+		OutputFormat: log.OutputFormatJSON,
+		LogLevel:     log.Info,
+		LiveAttrs:    attrs,
+	}
+
+	logger := slog.NewSlogLogger(config)
+	logger.Info(context.Background(), "First message")
+	attrs.Store(map[string]string{"version": "2"})
+	logger.Info(context.Background(), "Second message")
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 2 || !strings.Contains(lines[0], `"version":"1"`) || !strings.Contains(lines[1], `"version":"2"`) {
+		t.Errorf("Expected live attrs in log output, got: %s", buf.String())
+	}
+}
+
+// TestNew tests that New validates the config.
+func TestNew(t *testing.T) {
+	var buf strings.Builder
+	logger, err := slog.New(log.Config{Outputs: []io.Writer{&buf}})
+	if err != nil || logger == nil {
+		t.Fatalf("New returned an unexpected error: %v", err)
+	}
+
+	logger, err = slog.New(log.Config{OutputFormat: "XML"})
+	if err == nil || logger != nil {
+		t.Fatalf("Expected New to fail for an invalid config, got %v", logger)
+	}
+	for _, field := range []string{"OutputFormat", "Outputs"} {
+		if !strings.Contains(err.Error(), field) {
+			t.Errorf("Expected error to mention %s, got: %v", field, err)
+		}
+	}
+}
+
+func TestBackendRegistered(t *testing.T) {
+	config := log.Config{Backend: slog.BackendName, Outputs: []io.Writer{&strings.Builder{}}}
+	logger, err := log.New("", config)
+	if err != nil {
+		t.Fatalf("log.New returned an unexpected error: %v", err)
+	}
+	if _, ok := logger.(*slog.SlogLogger); !ok {
+		t.Errorf("Expected a slog.SlogLogger, got %T", logger)
+	}
+	if _, err := log.New(slog.BackendName, log.Config{}); err == nil {
+		t.Errorf("Expected log.New to validate the config")
+	}
+}
+
+func TestDefault_PackageFunctionsCaller(t *testing.T) {
+	var buf strings.Builder
+	config := log.Config{
+		Outputs:      []io.Writer{&buf},
+		OutputFormat: log.OutputFormatJSON,
+		Caller:       log.Caller{Enabled: true},
+	}
+	log.SetDefault(slog.NewSlogLogger(config))
+	defer log.SetDefault(nil)
+
+	log.InfoContext(context.Background(), "Package message")
+	_, file, line, _ := runtime.Caller(0)
+	if expected := fmt.Sprintf("%s:%d", file, line-1); !strings.Contains(buf.String(), expected) {
+		t.Errorf("Expected caller %q in log output, got: %s", expected, buf.String())
+	}
+}
+
+func TestDefault_Builtin(t *testing.T) {
+	log.SetDefault(nil)
+	logger := log.Default()
+	if _, ok := logger.(*slog.SlogLogger); !ok {
+		t.Fatalf("Expected the built-in default logger to use the slog backend, got %T", logger)
+	}
+	if logger.Enabled(context.Background(), log.Debug) || !logger.Enabled(context.Background(), log.Info) {
+		t.Errorf("Expected the built-in default logger to log at Info level")
+	}
+	if log.Default() != logger {
+		t.Errorf("Expected the built-in default logger to be built once")
+	}
+}
+
+func TestStdLogBridge_Caller(t *testing.T) {
+	var buf strings.Builder
+	config := log.Config{
+		Outputs:      []io.Writer{&buf},
+		OutputFormat: log.OutputFormatJSON,
+		Caller:       log.Caller{Enabled: true},
+	}
+	logger := slog.NewSlogLogger(config)
+
+	log.NewStdLogger(logger, log.Warn).Printf("Bridged %s", "message")
+	_, file, line, _ := runtime.Caller(0)
+	if expected := fmt.Sprintf("%s:%d", file, line-1); !strings.Contains(buf.String(), expected) {
+		t.Errorf("Expected caller %q in log output, got: %s", expected, buf.String())
+	}
+
+	buf.Reset()
+	restore := log.RedirectStdLog(logger)
+	stdlog.Print("Redirected message")
+	_, file, line, _ = runtime.Caller(0)
+	restore()
+	if expected := fmt.Sprintf("%s:%d", file, line-1); !strings.Contains(buf.String(), expected) {
+		t.Errorf("Expected caller %q in log output, got: %s", expected, buf.String())
+	}
+}
+
+func TestSyncAndClose(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "app.log")
+	f, err := os.Create(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	logger := slog.NewSlogLogger(log.Config{Outputs: []io.Writer{f}})
+
+	logger.Info(context.Background(), "Last message")
+	if err := logger.Sync(); err != nil {
+		t.Errorf("Sync returned an unexpected error: %v", err)
+	}
+	if err := logger.Close(); err != nil {
+		t.Errorf("Close returned an unexpected error: %v", err)
+	}
+	if _, err := f.Write([]byte("x")); !errors.Is(err, os.ErrClosed) {
+		t.Errorf("Expected Close to close the file, got %v", err)
+	}
+	if data, _ := os.ReadFile(path); !strings.Contains(string(data), "Last message") {
+		t.Errorf("Expected the entry in the file, got: %s", data)
+	}
+}
+
+func TestFatal_ExitFunc(t *testing.T) {
+	var buf strings.Builder
+	config := log.Config{
+		Outputs:      []io.Writer{&buf},
+		OutputFormat: log.OutputFormatJSON,
+		ExitFunc:     log.PanicOnFatal,
+	}
+	hooked := false
+	remove := log.OnFatal(func() { hooked = true })
+	defer remove()
+
+	logger := slog.NewSlogLogger(config)
+	defer func() {
+		if r := recover(); r != log.ErrFatal {
+			t.Errorf("Expected panic with log.ErrFatal, got: %v", r)
+		}
+		if !hooked {
+			t.Errorf("Expected the fatal hook to run before exiting")
+		}
+		if !strings.Contains(buf.String(), `"level":"FATAL"`) {
+			t.Errorf("Expected fatal entry in log output, got: %s", buf.String())
+		}
+	}()
+	logger.Fatal(context.Background(), "Fatal message")
+}
+
+func TestRecover_Caller(t *testing.T) {
+	var buf strings.Builder
+	config := log.Config{
+		Outputs:      []io.Writer{&buf},
+		OutputFormat: log.OutputFormatJSON,
+		Caller:       log.Caller{Enabled: true},
+	}
+	logger := slog.NewSlogLogger(config)
+
+	var file string
+	var line int
+	func() {
+		defer log.Recover(context.Background(), logger)
+		_, file, line, _ = runtime.Caller(0)
+		panic("boom")
+	}()
+	expected := fmt.Sprintf("%s:%d", file, line+1)
+	if !strings.Contains(buf.String(), `"caller":"`+expected) {
+		t.Errorf("Expected caller %q in log output, got: %s", expected, buf.String())
+	}
+	if !strings.Contains(buf.String(), `"panic":"boom"`) || !strings.Contains(buf.String(), `"panic_stack":"`+expected) {
+		t.Errorf("Expected the panic value and stack trace in log output, got: %s", buf.String())
+	}
+}
+
+func TestErrorFields(t *testing.T) {
+	err := fmt.Errorf("load config: %w", io.EOF)
+	for _, format := range []log.OutputFormat{log.OutputFormatJSON, log.OutputFormatTEXT} {
+		var buf strings.Builder
+		logger := slog.NewSlogLogger(log.Config{Outputs: []io.Writer{&buf}, OutputFormat: format})
+
+		logger.Error(context.Background(), "Failed", log.Err(err), "cause", io.ErrUnexpectedEOF)
+
+		out := buf.String()
+		for _, expected := range []string{"load config: EOF", "*fmt.wrapError", "*errors.errorString", "unexpected EOF", "chain"} {
+			if !strings.Contains(out, expected) {
+				t.Errorf("Expected %q in %s output, got: %s", expected, format, out)
+			}
+		}
+	}
+}
+
+func TestTypedFields(t *testing.T) {
+	var buf strings.Builder
+	logger := slog.NewSlogLogger(log.Config{Outputs: []io.Writer{&buf}, OutputFormat: log.OutputFormatJSON})
+
+	logger.Info(context.Background(), "Typed",
+		log.String("s", "v"), log.Int("i", 42), log.Float64("f", 1.5), log.Bool("b", true),
+		log.Duration("d", time.Second), log.Time("t", time.Unix(0, 0).UTC()),
+		log.Bytes("bytes", []byte("hi")), log.Object("o", map[string]int{"a": 1}))
+
+	for _, expected := range []string{`"s":"v"`, `"i":42`, `"f":1.5`, `"b":true`, `"d":1000000000`, `"t":"1970-01-01T00:00:00Z"`, `"bytes":"aGk="`, `"o":{"a":1}`} {
+		if !strings.Contains(buf.String(), expected) {
+			t.Errorf("Expected %s in log output, got: %s", expected, buf.String())
+		}
+	}
+}
+
+// TestTypedFields_Allocs checks that the backend converts and encodes typed fields
+// without allocating. The fields are built in advance, since passing them to the
+// Logger allocates. There are no more than five fields
+// and no float, since slog.Record allocates for more attributes and slog.JSONHandler
+// encodes floats with encoding/json.
+func TestLogFields_Allocs(t *testing.T) {
+	if raceEnabled {
+		t.Skip("The race detector drops items from sync.Pool")
+	}
+	logger := slog.NewSlogLogger(log.Config{Outputs: []io.Writer{io.Discard}, OutputFormat: log.OutputFormatJSON}).(*slog.SlogLogger)
+	ctx := context.Background()
+	now := time.Now()
+
+	allocs := testing.AllocsPerRun(100, func() {
+		logger.LogFields(ctx, log.Info, "Typed", log.String("s", "v"), log.Int("i", 42), log.Bool("b", true), log.Duration("d", time.Second), log.Time("t", now))
+	})
+	if allocs != 0 {
+		t.Errorf("Expected no allocations, got %v", allocs)
+	}
+}
+
+func TestLogFields(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.NewSlogLogger(log.Config{Outputs: []io.Writer{&buf}, OutputFormat: log.OutputFormatJSON}).WithGroup("http")
+
+	fl, ok := logger.(log.FieldLogger)
+	if !ok {
+		t.Fatalf("Expected %T to implement log.FieldLogger", logger)
+	}
+	fl.LogFields(context.Background(), log.Warn, "Typed", log.Int("status", 502))
+	fl.LogFields(context.Background(), log.Debug, "Disabled", log.Int("status", 200))
+
+	var entry map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &entry); err != nil {
+		t.Fatalf("Expected a single JSON entry, got %q: %v", buf.String(), err)
+	}
+	if entry["msg"] != "Typed" || !reflect.DeepEqual(entry["http"], map[string]any{"status": float64(502)}) {
+		t.Errorf("Unexpected entry: %v", entry)
+	}
+}
+
+func TestLogFields_Panic(t *testing.T) {
+	logger := slog.NewSlogLogger(log.Config{Outputs: []io.Writer{io.Discard}}).(log.FieldLogger)
+	defer func() {
+		if r := recover(); r != "Panic message" {
+			t.Errorf("Expected panic with %q, got %v", "Panic message", r)
+		}
+	}()
+	logger.LogFields(context.Background(), log.Panic, "Panic message")
+}
+
+func BenchmarkSlogLogger_TypedFields(b *testing.B) {
+	logger := slog.NewSlogLogger(log.Config{Outputs: []io.Writer{io.Discard}, OutputFormat: log.OutputFormatJSON})
+	ctx := context.Background()
+	now := time.Now()
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		logger.Info(ctx, "Typed", log.String("s", "v"), log.Int("i", i), log.Bool("b", true), log.Duration("d", time.Duration(i)), log.Time("t", now))
+	}
+}
+
+func BenchmarkSlogLogger_LogFields(b *testing.B) {
+	logger := slog.NewSlogLogger(log.Config{Outputs: []io.Writer{io.Discard}, OutputFormat: log.OutputFormatJSON}).(*slog.SlogLogger)
+	ctx := context.Background()
+	now := time.Now()
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		logger.LogFields(ctx, log.Info, "Typed", log.String("s", "v"), log.Int("i", i), log.Bool("b", true), log.Duration("d", time.Duration(i)), log.Time("t", now))
+	}
+}
+
+func BenchmarkSlogLogger_KeysAndValues(b *testing.B) {
+	logger := slog.NewSlogLogger(log.Config{Outputs: []io.Writer{io.Discard}, OutputFormat: log.OutputFormatJSON})
+	ctx := context.Background()
+	now := time.Now()
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		logger.Info(ctx, "Untyped", "s", "v", "i", i, "b", true, "d", time.Duration(i), "t", now)
+	}
+}
+
+func TestMalformedKeysAndValues(t *testing.T) {
+	var buf strings.Builder
+	logger := slog.NewSlogLogger(log.Config{Outputs: []io.Writer{&buf}, OutputFormat: log.OutputFormatJSON})
+
+	logger.Info(context.Background(), "Malformed", "a", 1, 42, "b")
+
+	for _, expected := range []string{`"a":1`, `"!BADKEY":42`, `"b":"!MISSING"`} {
+		if !strings.Contains(buf.String(), expected) {
+			t.Errorf("Expected %s in log output, got: %s", expected, buf.String())
+		}
+	}
+}
+
+func TestStrict(t *testing.T) {
+	var errs []error
+	logger := slog.NewSlogLogger(log.Config{
+		Outputs:      []io.Writer{io.Discard},
+		OutputFormat: log.OutputFormatJSON,
+		Strict:       true,
+		OnError:      func(err error) { errs = append(errs, err) },
+	})
+	ctx := context.Background()
+
+	_, file, line, _ := runtime.Caller(0)
+	logger.Info(ctx, "Malformed", 42, "v")
+	logger.With("k").Info(ctx, "Well-formed", "a", 1)
+
+	if len(errs) != 2 {
+		t.Fatalf("Expected 2 errors, got %v", errs)
+	}
+	for i, err := range errs {
+		var kvErr *log.KeysAndValuesError
+		if !errors.As(err, &kvErr) {
+			t.Fatalf("Expected a *log.KeysAndValuesError, got %v", err)
+		}
+		if expected := fmt.Sprintf("%s:%d", filepath.Base(file), line+1+i); !strings.Contains(kvErr.Caller, expected) {
+			t.Errorf("Expected caller %s, got %s", expected, kvErr.Caller)
+		}
+	}
+}
+
+// ptrError has an Error method that panics for a nil receiver.
+type ptrError struct{ msg string }
+
+func (e *ptrError) Error() string { return e.msg }
+
+func TestErrorFields_TypedNil(t *testing.T) {
+	var buf strings.Builder
+	logger := slog.NewSlogLogger(log.Config{Outputs: []io.Writer{&buf}, OutputFormat: log.OutputFormatJSON})
+	var err *ptrError
+
+	logger.Info(context.Background(), "Failed", "err", err, log.Err(err))
+
+	for _, expected := range []string{`"err":"<nil>"`, `"error":"<nil>"`} {
+		if !strings.Contains(buf.String(), expected) {
+			t.Errorf("Expected %s in log output, got: %s", expected, buf.String())
+		}
+	}
+}
+
+// TestSlogLogger_LiveAttrs_WithGroup tests that live attributes stay at the top level of
+// grouped entries, like static attributes.
+func TestSlogLogger_LiveAttrs_WithGroup(t *testing.T) {
+	var buf strings.Builder
+	logger := slog.NewSlogLogger(log.Config{
+		Outputs:      []io.Writer{&buf},
+		OutputFormat: log.OutputFormatJSON,
+		Attrs:        map[string]string{"static": "x"},
+		LiveAttrs:    log.NewAtomicAttrs(map[string]string{"service": "api"}),
+	})
+
+	logger.WithGroup("http").Info(context.Background(), "Request served", "status", 200)
+
+	var entry map[string]any
+	if err := json.Unmarshal([]byte(buf.String()), &entry); err != nil {
+		t.Fatalf("Failed to parse log output %s: %v", buf.String(), err)
+	}
+	group, _ := entry["http"].(map[string]any)
+	if entry["static"] != "x" || entry["service"] != "api" || group["service"] != nil {
+		t.Errorf("Expected static and live attributes at the top level, got: %s", buf.String())
+	}
+}
+
+// TestSlogLogger_ContextFields_Malformed tests that a malformed keysAndValues does not shift
+// the fields stored in the context.
+func TestSlogLogger_ContextFields_Malformed(t *testing.T) {
+	var buf strings.Builder
+	logger := slog.NewSlogLogger(log.Config{Outputs: []io.Writer{&buf}, OutputFormat: log.OutputFormatJSON})
+	ctx := log.ContextWithFields(context.Background(), "request_id", "abc")
+
+	logger.Info(ctx, "Malformed", "user")
+
+	for _, expected := range []string{`"user":"!MISSING"`, `"request_id":"abc"`} {
+		if !strings.Contains(buf.String(), expected) {
+			t.Errorf("Expected %s in log output, got: %s", expected, buf.String())
+		}
+	}
+}
+
+// TestSlogLogger_ContextFields_WithGroup tests that fields stored in the context are logged
+// at the top level rather than in the groups of the logger.
+func TestSlogLogger_ContextFields_WithGroup(t *testing.T) {
+	for _, format := range []log.OutputFormat{log.OutputFormatJSON, log.OutputFormatTEXT} {
+		t.Run(string(format), func(t *testing.T) {
+			var buf strings.Builder
+			logger := slog.NewSlogLogger(log.Config{Outputs: []io.Writer{&buf}, OutputFormat: format}).WithGroup("http")
+			ctx := log.ContextWithFields(context.Background(), "request_id", "abc")
+
+			logger.Info(ctx, "Grouped", "method", "GET")
+
+			if strings.Contains(buf.String(), "http.request_id") || strings.Count(buf.String(), "request_id") != 1 {
+				t.Errorf("Expected request_id at the top level, got: %s", buf.String())
+			}
+			if format == log.OutputFormatJSON {
+				var entry map[string]any
+				if err := json.Unmarshal([]byte(buf.String()), &entry); err != nil {
+					t.Fatalf("Failed to parse %q: %v", buf.String(), err)
+				}
+				if entry["request_id"] != "abc" || !reflect.DeepEqual(entry["http"], map[string]any{"method": "GET"}) {
+					t.Errorf("Expected request_id at the top level, got: %s", buf.String())
+				}
+			}
+		})
+	}
+}
+
+// TestSlogLogger_WithGroup_Empty tests that groups without fields are omitted.
+func TestSlogLogger_WithGroup_Empty(t *testing.T) {
+	var buf strings.Builder
+	logger := slog.NewSlogLogger(log.Config{Outputs: []io.Writer{&buf}, OutputFormat: log.OutputFormatJSON})
+
+	logger.WithGroup("http").Info(context.Background(), "Empty group")
+	logger.WithGroup("http").With("method", "GET").WithGroup("request").Info(context.Background(), "Empty nested group")
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("Expected 2 entries, got: %s", buf.String())
+	}
+	var entry map[string]any
+	if err := json.Unmarshal([]byte(lines[0]), &entry); err != nil || entry["http"] != nil {
+		t.Errorf("Expected no http group, got: %s", lines[0])
+	}
+	entry = nil
+	if err := json.Unmarshal([]byte(lines[1]), &entry); err != nil || !reflect.DeepEqual(entry["http"], map[string]any{"method": "GET"}) {
+		t.Errorf("Expected an http group without the request group, got: %s", lines[1])
+	}
+}
@@ -0,0 +1,151 @@
+package zap
+
+import (
+	"context"
+	"sort"
+
+	"github.com/prakashpandey/golog/log"
+	"go.uber.org/zap/zapcore"
+)
+
+// coreCallerSkip is the number of stack frames between core.Write and the caller
+// of a *zap.Logger method: zapcore.CheckedEntry.Write and the method, e.g. Info.
+// It is used for entries without caller information.
+const coreCallerSkip = 3
+
+// core is a zapcore.Core that writes entries to a golog Logger.
+type core struct {
+	logger log.Logger
+}
+
+// NewCore returns a zapcore.Core that writes the entries of a *zap.Logger to
+// logger, so that libraries logging with zap write through any golog backend:
+//
+//	zap.New(NewCore(logger)).Info("msg", zap.String("key", "value"))
+//
+// The level of logger decides which entries are written. Levels of named loggers
+// in Config.NamedLevels apply to the names of zap.Logger.Named, but can only raise
+// that level. Levels map to the closest golog level, where zap's DPanic, Panic and
+// Fatal levels are logged at log.Critical, since *zap.Logger panics and exits on its own.
+// Namespaces are passed to Logger.WithGroup and errors are passed as error values.
+// The entry's caller is reported as caller if the zap.Logger records it, see zap.AddCaller.
+// The entry's time is ignored, since the backends time entries when they write them,
+// and its stack trace in favor of Config.Stacktrace.
+func NewCore(logger log.Logger) zapcore.Core {
+	return &core{logger: logger.WithCallerSkip(coreCallerSkip)}
+}
+
+// Enabled implements zapcore.LevelEnabler.
+func (c *core) Enabled(level zapcore.Level) bool {
+	return c.logger.Enabled(context.Background(), convertZapLevel(level))
+}
+
+// With implements zapcore.Core.
+func (c *core) With(fields []zapcore.Field) zapcore.Core {
+	logger, keysAndValues := applyFields(c.logger, fields)
+	if len(keysAndValues) > 0 {
+		logger = logger.With(keysAndValues...)
+	}
+	return &core{logger: logger}
+}
+
+// Check implements zapcore.Core.
+func (c *core) Check(entry zapcore.Entry, checked *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if c.named(entry).Enabled(context.Background(), convertZapLevel(entry.Level)) {
+		return checked.AddCore(entry, c)
+	}
+	return checked
+}
+
+// Write implements zapcore.Core.
+func (c *core) Write(entry zapcore.Entry, fields []zapcore.Field) error {
+	logger, keysAndValues := applyFields(c.named(entry), fields)
+	ctx := context.Background()
+	if entry.Caller.Defined {
+		ctx = log.ContextWithCaller(ctx, entry.Caller.PC)
+	}
+
+	switch convertZapLevel(entry.Level) {
+	case log.Trace:
+		logger.Trace(ctx, entry.Message, keysAndValues...)
+	case log.Debug:
+		logger.Debug(ctx, entry.Message, keysAndValues...)
+	case log.Info:
+		logger.Info(ctx, entry.Message, keysAndValues...)
+	case log.Notice:
+		logger.Notice(ctx, entry.Message, keysAndValues...)
+	case log.Warn:
+		logger.Warn(ctx, entry.Message, keysAndValues...)
+	case log.Error:
+		logger.Error(ctx, entry.Message, keysAndValues...)
+	default:
+		logger.Critical(ctx, entry.Message, keysAndValues...)
+	}
+	return nil
+}
+
+// Sync implements zapcore.Core by flushing the outputs of the logger.
+func (c *core) Sync() error {
+	return c.logger.Sync()
+}
+
+// named returns the logger named after the entry's logger name.
+func (c *core) named(entry zapcore.Entry) log.Logger {
+	if entry.LoggerName == "" {
+		return c.logger
+	}
+	return c.logger.Named(entry.LoggerName)
+}
+
+// convertZapLevel converts a zap level to the closest golog level.
+func convertZapLevel(level zapcore.Level) log.Level {
+	switch level {
+	case traceLevel:
+		return log.Trace
+	case noticeLevel:
+		return log.Notice
+	case zapcore.DebugLevel:
+		return log.Debug
+	case zapcore.InfoLevel:
+		return log.Info
+	case zapcore.WarnLevel:
+		return log.Warn
+	case zapcore.ErrorLevel:
+		return log.Error
+	}
+	if level < zapcore.DebugLevel {
+		return log.Trace
+	}
+	return log.Critical
+}
+
+// applyFields converts fields to keys and values. The fields following a namespace
+// are nested in a group of the returned logger, which is logger if there is none.
+func applyFields(logger log.Logger, fields []zapcore.Field) (log.Logger, []any) {
+	keysAndValues := make([]any, 0, 2*len(fields))
+	for _, f := range fields {
+		switch f.Type {
+		case zapcore.SkipType:
+		case zapcore.NamespaceType:
+			if len(keysAndValues) > 0 {
+				logger = logger.With(keysAndValues...)
+				keysAndValues = keysAndValues[:0:0]
+			}
+			logger = logger.WithGroup(f.Key)
+		case zapcore.ErrorType:
+			keysAndValues = append(keysAndValues, f.Key, f.Interface)
+		default:
+			enc := zapcore.NewMapObjectEncoder()
+			f.AddTo(enc)
+			keys := make([]string, 0, len(enc.Fields))
+			for key := range enc.Fields {
+				keys = append(keys, key)
+			}
+			sort.Strings(keys)
+			for _, key := range keys {
+				keysAndValues = append(keysAndValues, key, enc.Fields[key])
+			}
+		}
+	}
+	return logger, keysAndValues
+}
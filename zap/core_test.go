@@ -0,0 +1,95 @@
+package zap
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"runtime"
+	"testing"
+
+	"github.com/prakashpandey/golog/log"
+	"github.com/prakashpandey/golog/slog"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+func TestNewCore(t *testing.T) {
+	var buf bytes.Buffer
+	config := log.Config{
+		Outputs:      []io.Writer{&buf},
+		OutputFormat: log.OutputFormatJSON,
+		LogLevel:     log.Info,
+		NamedLevels:  log.NewNamedLevels(map[string]log.Level{"noisy": log.Error}),
+	}
+	// The slog backend shows that entries flow into any golog backend.
+	logger := zap.New(NewCore(slog.NewSlogLogger(config)))
+
+	logger.Debug("Debug message")
+	logger.Named("noisy").Warn("Noisy message")
+	if buf.Len() != 0 {
+		t.Fatalf("Expected disabled entries to be dropped, got: %s", buf.String())
+	}
+
+	logger.With(zap.String("service", "api")).Warn("Handled",
+		zap.Error(errors.New("boom")), zap.Namespace("req"), zap.Int("id", 7), zap.Strings("tags", []string{"a"}))
+
+	var entry map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &entry); err != nil {
+		t.Fatalf("Invalid JSON output %q: %v", buf.String(), err)
+	}
+	if entry["level"] != "WARN" || entry["msg"] != "Handled" || entry["service"] != "api" {
+		t.Errorf("Unexpected entry: %v", entry)
+	}
+	if e, _ := entry["error"].(map[string]any); e["msg"] != "boom" {
+		t.Errorf("Expected the error details, got %v", entry["error"])
+	}
+	req, _ := entry["req"].(map[string]any)
+	if req["id"] != float64(7) || fmt.Sprint(req["tags"]) != "[a]" {
+		t.Errorf("Unexpected namespace: %v", entry["req"])
+	}
+}
+
+func TestNewCore_Levels(t *testing.T) {
+	tests := map[zapcore.Level]log.Level{
+		zapcore.DebugLevel - 3: log.Trace,
+		traceLevel:             log.Trace,
+		noticeLevel:            log.Notice,
+		zapcore.DebugLevel:     log.Debug,
+		zapcore.InfoLevel:      log.Info,
+		zapcore.WarnLevel:      log.Warn,
+		zapcore.ErrorLevel:     log.Error,
+		zapcore.DPanicLevel:    log.Critical,
+		zapcore.PanicLevel:     log.Critical,
+		zapcore.FatalLevel:     log.Critical,
+	}
+	for level, expected := range tests {
+		if got := convertZapLevel(level); got != expected {
+			t.Errorf("convertZapLevel(%v) = %v, expected %v", level, got, expected)
+		}
+	}
+}
+
+func TestNewCore_Caller(t *testing.T) {
+	var buf bytes.Buffer
+	config := log.Config{
+		Outputs:      []io.Writer{&buf},
+		OutputFormat: log.OutputFormatJSON,
+		Caller:       log.Caller{Enabled: true},
+	}
+	golog := NewZapLogger(config)
+
+	zap.New(NewCore(golog), zap.AddCaller()).Info("With zap caller")
+	_, file, line, _ := runtime.Caller(0)
+	if expected := fmt.Sprintf("%s:%d", file, line-1); !bytes.Contains(buf.Bytes(), []byte(expected)) {
+		t.Errorf("Expected caller %q in log output, got: %s", expected, buf.String())
+	}
+
+	buf.Reset()
+	zap.New(NewCore(golog)).Info("Without zap caller")
+	_, file, line, _ = runtime.Caller(0)
+	if expected := fmt.Sprintf("%s:%d", file, line-1); !bytes.Contains(buf.Bytes(), []byte(expected)) {
+		t.Errorf("Expected caller %q in log output, got: %s", expected, buf.String())
+	}
+}
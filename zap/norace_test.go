@@ -0,0 +1,7 @@
+//go:build !race
+
+package zap
+
+// raceEnabled reports whether the race detector is enabled, which makes sync.Pool
+// drop items and allocation counts unreliable.
+const raceEnabled = false
@@ -2,6 +2,9 @@ package zap
 
 import (
 	"context"
+	"io"
+	"slices"
+	"sync"
 
 	"github.com/prakashpandey/golog/caller"
 	"github.com/prakashpandey/golog/log"
@@ -11,47 +14,174 @@ import (
 
 // ZapLogger is an implementation of Logger interface using Uber's Zap.
 type ZapLogger struct {
-	logger *zap.Logger
+	logger *zap.Logger // Logger without the groups, which logs the metadata of entries at the top level.
+	group  []zap.Field // Namespaces opened with WithGroup in JSON format and the fields added to them with With.
+	prefix string      // Group prefix for keys in TEXT format, e.g. "http."
+	name   string      // Dot-separated logger name, empty for the root logger.
+	skip   int         // Additional stack frames to skip, see WithCallerSkip.
 	log.Config
 }
 
+// Custom zap levels for the golog levels that zap does not define.
+// Zap has no level between InfoLevel and WarnLevel, so noticeLevel does not sort
+// by severity and levels are compared by their golog level instead, see levelEnabler.
+const (
+	traceLevel  = zapcore.DebugLevel - 1
+	noticeLevel = zapcore.DebugLevel - 2
+)
+
+// levelNames holds the names of the zap levels used by ZapLogger.
+var levelNames = map[zapcore.Level]string{
+	traceLevel:          "trace",
+	zapcore.DebugLevel:  "debug",
+	zapcore.InfoLevel:   "info",
+	noticeLevel:         "notice",
+	zapcore.WarnLevel:   "warn",
+	zapcore.ErrorLevel:  "error",
+	zapcore.DPanicLevel: "critical",
+	zapcore.PanicLevel:  "panic",
+	zapcore.FatalLevel:  "fatal",
+}
+
 // convertLogLevel converts a custom log.Level to zapcore.Level.
 func convertLogLevel(level log.Level) zapcore.Level {
 	var l zapcore.Level
 	switch level {
+	case log.Trace:
+		l = traceLevel
 	case log.Debug:
 		l = zapcore.DebugLevel
 	case log.Info:
 		l = zapcore.InfoLevel
+	case log.Notice:
+		l = noticeLevel
 	case log.Warn:
 		l = zapcore.WarnLevel
 	case log.Error:
 		l = zapcore.ErrorLevel
+	case log.Critical:
+		l = zapcore.DPanicLevel
+	case log.Panic:
+		l = zapcore.PanicLevel
+	case log.Fatal:
+		l = zapcore.FatalLevel
 	default:
 		l = zapcore.InfoLevel
 	}
 	return l
 }
 
+// nativeLevel converts a minimum log level to a zapcore.Level for a zapcore.Core.
+// An unset level enables every level and Off disables all of them.
+func nativeLevel(level log.Level) zapcore.Level {
+	switch {
+	case level == 0:
+		return traceLevel
+	case level >= log.Off:
+		return zapcore.FatalLevel + 1
+	default:
+		return convertLogLevel(level)
+	}
+}
+
+// goLevel converts a zapcore.Level returned by nativeLevel back to a log.Level.
+func goLevel(level zapcore.Level) log.Level {
+	switch level {
+	case traceLevel:
+		return log.Trace
+	case zapcore.DebugLevel:
+		return log.Debug
+	case zapcore.InfoLevel:
+		return log.Info
+	case noticeLevel:
+		return log.Notice
+	case zapcore.WarnLevel:
+		return log.Warn
+	case zapcore.ErrorLevel:
+		return log.Error
+	case zapcore.DPanicLevel:
+		return log.Critical
+	case zapcore.PanicLevel:
+		return log.Panic
+	case zapcore.FatalLevel:
+		return log.Fatal
+	default:
+		return log.Off
+	}
+}
+
+// levelEnabler is a zapcore.LevelEnabler that reads the lowest level of a log.Config
+// on every call, see log.Config.MinLevel, so that the core follows changes of
+// config.Level and config.NamedLevels. It compares levels by their golog level,
+// so that noticeLevel sorts between InfoLevel and WarnLevel.
+type levelEnabler struct {
+	config log.Config
+}
+
+func (e *levelEnabler) Enabled(level zapcore.Level) bool {
+	return goLevel(level) >= goLevel(e.Level())
+}
+
+// Level returns the lowest enabled level, see zapcore.LevelOf.
+func (e *levelEnabler) Level() zapcore.Level {
+	return nativeLevel(e.config.MinLevel())
+}
+
+// encodeLevel encodes the golog name of a level, e.g. "critical" rather than "dpanic".
+func encodeLevel(level zapcore.Level, enc zapcore.PrimitiveArrayEncoder) {
+	if name, ok := levelNames[level]; ok {
+		enc.AppendString(name)
+		return
+	}
+	zapcore.LowercaseLevelEncoder(level, enc)
+}
+
 // NewZapLogger creates a new instance of ZapLogger with the given configuration.
+// The configuration is not validated, see New.
 func NewZapLogger(config log.Config) log.Logger {
 	config.Sanitize()
 	config.Default()
+	return newZapLogger(config)
+}
+
+// BackendName is the name under which this package registers its backend with
+// log.RegisterBackend, so that log.New(BackendName, config) builds a ZapLogger.
+const BackendName = "zap"
+
+func init() {
+	log.RegisterBackend(BackendName, New)
+}
+
+// New creates a new instance of ZapLogger with the given configuration.
+// It returns the errors reported by config.Validate instead of a logger for an invalid configuration.
+func New(config log.Config) (log.Logger, error) {
+	config.Sanitize()
+	config.Default()
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+	return newZapLogger(config), nil
+}
+
+// newZapLogger creates a ZapLogger for a sanitized configuration with defaults applied.
+func newZapLogger(config log.Config) log.Logger {
 	zapConfig := zap.NewProductionConfig()
+	zapConfig.EncoderConfig.NameKey = config.NameField
+	zapConfig.EncoderConfig.EncodeLevel = encodeLevel
+	// The cores enforce the lowest level of the logger and its named loggers, so
+	// that a change of config.Level takes effect in zap too. ZapLogger enforces the
+	// exact level of each named logger.
+	level := &levelEnabler{config}
 	var cores []zapcore.Core
 	for _, output := range config.Outputs {
-		writer := zapcore.AddSync(output)
+		writer := outputSyncer{output}
 		var encoder zapcore.Encoder
 		if config.OutputFormat == log.OutputFormatJSON {
 			encoder = zapcore.NewJSONEncoder(zapConfig.EncoderConfig)
 		} else {
 			encoder = zapcore.NewConsoleEncoder(zapConfig.EncoderConfig)
 		}
-		core := zapcore.NewCore(
-			encoder,
-			writer,
-			convertLogLevel(config.LogLevel),
-		)
+		core := zapcore.NewCore(encoder, writer, level)
 
 		cores = append(cores, core)
 	}
@@ -65,6 +195,10 @@ func NewZapLogger(config log.Config) log.Logger {
 		zap.AddCallerSkip(0),
 		zap.AddStacktrace(zap.ErrorLevel),
 		zap.Fields(attrs...),
+		// Panic and Fatal are handled by ZapLogger, so that both backends
+		// panic and exit in the same way.
+		zap.WithPanicHook(continueHook{}),
+		zap.WithFatalHook(continueHook{}),
 	)
 
 	return &ZapLogger{
@@ -75,57 +209,407 @@ func NewZapLogger(config log.Config) log.Logger {
 }
 
 // convertToZapFields converts keysAndValues to zap.Fields.
+// Typed log.Fields are converted without reflection and errors are logged as
+// log.ErrorDetails objects.
 func convertToZapFields(keysAndValues ...any) []zap.Field {
-	fields := make([]zap.Field, 0, len(keysAndValues)/2)
-	for i := 0; i < len(keysAndValues); i += 2 {
-		if i+1 < len(keysAndValues) {
-			key, ok := keysAndValues[i].(string)
-			if !ok {
-				continue
-			}
-			fields = append(fields, zap.Any(key, keysAndValues[i+1]))
+	fields, _ := appendZapFields(make([]zap.Field, 0, len(keysAndValues)/2), keysAndValues)
+	return fields
+}
+
+// appendZapFields appends keysAndValues converted to zap.Fields to fields, see
+// log.NextField. It returns the index of the first malformed element of
+// keysAndValues, or -1.
+func appendZapFields(fields []zap.Field, keysAndValues []any) ([]zap.Field, int) {
+	bad := -1
+	for i := 0; i < len(keysAndValues); {
+		f, next, ok := log.NextField(keysAndValues, i)
+		if !ok && bad < 0 {
+			bad = i
 		}
+		fields = append(fields, zapField(f))
+		i = next
 	}
-	return fields
+	return fields, bad
+}
+
+// zapField converts a log.Field to a zap.Field.
+func zapField(f log.Field) zap.Field {
+	switch f.Type {
+	case log.StringType:
+		return zap.String(f.Key, f.Str)
+	case log.Int64Type:
+		return zap.Int64(f.Key, f.Int)
+	case log.Float64Type:
+		return zap.Float64(f.Key, f.Float64())
+	case log.BoolType:
+		return zap.Bool(f.Key, f.Bool())
+	case log.DurationType:
+		return zap.Duration(f.Key, f.Duration())
+	case log.TimeType:
+		return zap.Time(f.Key, f.Time())
+	case log.BytesType:
+		b, _ := f.Value.([]byte)
+		return zap.Binary(f.Key, b)
+	case log.ObjectType:
+		if m, ok := f.Value.(zapcore.ObjectMarshaler); ok {
+			return zap.Object(f.Key, m)
+		}
+		return zap.Reflect(f.Key, f.Value)
+	case log.GroupType:
+		if len(f.Group()) == 0 {
+			return zap.Skip()
+		}
+		return zap.Object(f.Key, groupMarshaler(f.Group()))
+	default:
+		return anyZapField(f.Key, f.Value)
+	}
+}
+
+// groupMarshaler is a zapcore.ObjectMarshaler logging the fields of a log.Group.
+type groupMarshaler []log.Field
+
+// MarshalLogObject implements zapcore.ObjectMarshaler.
+func (g groupMarshaler) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	for _, f := range g {
+		zapField(f).AddTo(enc)
+	}
+	return nil
+}
+
+// anyZapField converts a key and a value of any type to a zap.Field.
+func anyZapField(key string, value any) zap.Field {
+	if err, ok := value.(error); ok && err != nil {
+		if log.IsNilError(err) {
+			return zap.String(key, "<nil>")
+		}
+		return zap.Object(key, errorMarshaler{err})
+	}
+	return zap.Any(key, value)
+}
+
+// errorMarshaler is a zapcore.ObjectMarshaler logging the log.ErrorDetails of an error.
+type errorMarshaler struct {
+	err error
+}
+
+// MarshalLogObject implements zapcore.ObjectMarshaler.
+func (m errorMarshaler) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	d := log.NewErrorDetails(m.err)
+	enc.AddString("msg", d.Msg)
+	enc.AddString("type", d.Type)
+	if len(d.Chain) > 0 {
+		if err := enc.AddArray("chain", errorChain(d.Chain)); err != nil {
+			return err
+		}
+	}
+	if d.Detail != "" {
+		enc.AddString("detail", d.Detail)
+	}
+	return nil
+}
+
+// errorChain is a zapcore.ArrayMarshaler for log.ErrorDetails.Chain.
+type errorChain []log.ErrorCause
+
+// MarshalLogArray implements zapcore.ArrayMarshaler.
+func (c errorChain) MarshalLogArray(enc zapcore.ArrayEncoder) error {
+	for _, cause := range c {
+		err := enc.AppendObject(zapcore.ObjectMarshalerFunc(func(enc zapcore.ObjectEncoder) error {
+			enc.AddString("msg", cause.Msg)
+			enc.AddString("type", cause.Type)
+			return nil
+		}))
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// fields converts keysAndValues to zap.Fields, prefixing keys with the
+// current group prefix.
+func (l *ZapLogger) fields(keysAndValues []any) ([]zap.Field, int) {
+	return l.appendFields(make([]zap.Field, 0, len(keysAndValues)/2), keysAndValues)
+}
+
+// appendFields appends keysAndValues converted to zap.Fields to fields, prefixing
+// keys with the current group prefix. It returns the index of the first malformed
+// element of keysAndValues, or -1.
+func (l *ZapLogger) appendFields(fields []zap.Field, keysAndValues []any) ([]zap.Field, int) {
+	n := len(fields)
+	fields, bad := appendZapFields(fields, keysAndValues)
+	if l.prefix != "" {
+		for i := n; i < len(fields); i++ {
+			fields[i].Key = l.prefix + fields[i].Key
+		}
+	}
+	return fields, bad
+}
+
+// fieldsPool holds buffers for the fields of entries, so that logging does not
+// allocate them. Zap encodes the fields before Log returns.
+var fieldsPool = sync.Pool{
+	New: func() any {
+		fields := make([]zap.Field, 0, 16)
+		return &fields
+	},
 }
 
-// stacktrace returns the keys and values with caller and stack trace information.
-// It appends caller and stack trace information to the keys and values if enabled.
-// Caller information is appended only if enabled.
-// Stack trace information is appended only if enabled and the log level is greater than or equal to the stack trace level.
-func (l *ZapLogger) stacktrace(level log.Level, keysAndValues []any) []any {
-	return caller.AddStacktrace(level, l.Config, keysAndValues)
+// callerSkip is the number of stack frames between metadata and the user's call site:
+// metadata itself, log and the exported logging method calling it.
+const callerSkip = 3
+
+// metadata returns the caller and stack trace information as keys and values, which
+// are logged at the top level of entries rather than in the groups of the logger.
+// Caller information is returned only if enabled.
+// Stack trace information is returned only if enabled and the log level is greater than or equal to the stack trace level.
+// Config.LiveAttrs are appended, like the static Config.Attrs of the logger.
+func (l *ZapLogger) metadata(ctx context.Context, level log.Level) []any {
+	config := l.Config
+	config.Caller.Skip += callerSkip + l.skip
+	return l.liveAttrs(caller.AddStacktraceContext(ctx, level, config, nil))
+}
+
+// liveAttrs returns keysAndValues with Config.LiveAttrs appended.
+func (l *ZapLogger) liveAttrs(keysAndValues []any) []any {
+	if l.LiveAttrs == nil {
+		return keysAndValues
+	}
+	attrs := l.LiveAttrs.KeysAndValues()
+	if len(keysAndValues) == 0 {
+		return attrs
+	}
+	return append(keysAndValues, attrs...)
+}
+
+// log writes an entry at level with keysAndValues and the typed fields. Callers
+// check whether level is enabled.
+func (l *ZapLogger) log(ctx context.Context, level log.Level, msg string, keysAndValues []any, typed []log.Field) {
+	buf := fieldsPool.Get().(*[]zap.Field)
+	fields, _ := appendZapFields((*buf)[:0], l.metadata(ctx, level))
+	// Fields stored in ctx with log.ContextWithFields are logged at the top level too,
+	// so that e.g. a request ID has the same key in every entry. They are converted
+	// on their own, so that a malformed keysAndValues cannot shift them.
+	fields, _ = appendZapFields(fields, log.FieldsFromContext(ctx))
+	fields = append(fields, l.group...)
+	fields, bad := l.appendFields(fields, keysAndValues)
+	if bad >= 0 {
+		log.ReportMalformed(ctx, l.Config, l.Caller.Skip+l.skip+2, keysAndValues[bad]) // log, e.g. Info
+	}
+	for _, f := range typed {
+		field := zapField(f)
+		if l.prefix != "" {
+			field.Key = l.prefix + field.Key
+		}
+		fields = append(fields, field)
+	}
+	// Namespaces without fields after them are dropped, so that empty groups are
+	// omitted like in the slog backend.
+	entry := fields
+	for len(entry) > 0 && entry[len(entry)-1].Type == zapcore.NamespaceType {
+		entry = entry[:len(entry)-1]
+	}
+	l.logger.Log(convertLogLevel(level), msg, entry...)
+	clear(fields) // Do not keep the values alive.
+	*buf = fields[:0]
+	fieldsPool.Put(buf)
+}
+
+// Trace logs a message at Trace level.
+func (l *ZapLogger) Trace(ctx context.Context, msg string, keysAndValues ...any) {
+	if l.Enabled(ctx, log.Trace) {
+		l.log(ctx, log.Trace, msg, keysAndValues, nil)
+	}
 }
 
-// Debug logs a message at DebugLevel.
+// Debug logs a message at Debug level.
 func (l *ZapLogger) Debug(ctx context.Context, msg string, keysAndValues ...any) {
-	if l.LogLevel <= log.Debug {
-		l.logger.Debug(msg, convertToZapFields(l.stacktrace(log.Debug, keysAndValues)...)...)
+	if l.Enabled(ctx, log.Debug) {
+		l.log(ctx, log.Debug, msg, keysAndValues, nil)
 	}
 }
 
-// Info logs a message at InfoLevel.
+// Info logs a message at Info level.
 func (l *ZapLogger) Info(ctx context.Context, msg string, keysAndValues ...any) {
-	if l.LogLevel <= log.Info {
-		l.logger.Info(msg, convertToZapFields(l.stacktrace(log.Info, keysAndValues)...)...)
+	if l.Enabled(ctx, log.Info) {
+		l.log(ctx, log.Info, msg, keysAndValues, nil)
 	}
 }
 
-// Warn logs a message at WarnLevel.
+// Notice logs a message at Notice level.
+func (l *ZapLogger) Notice(ctx context.Context, msg string, keysAndValues ...any) {
+	if l.Enabled(ctx, log.Notice) {
+		l.log(ctx, log.Notice, msg, keysAndValues, nil)
+	}
+}
+
+// Warn logs a message at Warn level.
 func (l *ZapLogger) Warn(ctx context.Context, msg string, keysAndValues ...any) {
-	if l.LogLevel <= log.Warn {
-		l.logger.Warn(msg, convertToZapFields(l.stacktrace(log.Warn, keysAndValues)...)...)
+	if l.Enabled(ctx, log.Warn) {
+		l.log(ctx, log.Warn, msg, keysAndValues, nil)
 	}
 }
 
-// Error logs a message at ErrorLevel.
+// Error logs a message at Error level.
 func (l *ZapLogger) Error(ctx context.Context, msg string, keysAndValues ...any) {
-	if l.LogLevel <= log.Error {
-		l.logger.Error(msg, convertToZapFields(l.stacktrace(log.Error, keysAndValues)...)...)
+	if l.Enabled(ctx, log.Error) {
+		l.log(ctx, log.Error, msg, keysAndValues, nil)
 	}
 }
 
-// Fatal logs a message at ErrorLevel and then calls os.Exit(1).
+// Critical logs a message at Critical level.
+func (l *ZapLogger) Critical(ctx context.Context, msg string, keysAndValues ...any) {
+	if l.Enabled(ctx, log.Critical) {
+		l.log(ctx, log.Critical, msg, keysAndValues, nil)
+	}
+}
+
+// With returns a child logger that carries keysAndValues on every entry.
+func (l *ZapLogger) With(keysAndValues ...any) log.Logger {
+	fields, bad := l.fields(keysAndValues)
+	if bad >= 0 {
+		log.ReportMalformed(context.Background(), l.Config, 1, keysAndValues[bad])
+	}
+	if len(l.group) > 0 {
+		return &ZapLogger{
+			logger: l.logger,
+			group:  append(slices.Clip(l.group), fields...),
+			prefix: l.prefix,
+			name:   l.name,
+			skip:   l.skip,
+			Config: l.Config,
+		}
+	}
+	return &ZapLogger{
+		logger: l.logger.With(fields...),
+		prefix: l.prefix,
+		name:   l.name,
+		skip:   l.skip,
+		Config: l.Config,
+	}
+}
+
+// WithGroup returns a child logger that nests subsequent fields under name.
+// In JSON format the group is a zap.Namespace, which yields a nested object.
+// Caller and stack trace information stay at the top level.
+// In TEXT format keys are prefixed with the group name to yield dotted keys,
+// matching the slog backend.
+func (l *ZapLogger) WithGroup(name string) log.Logger {
+	if name == "" {
+		return l
+	}
+	if l.OutputFormat == log.OutputFormatJSON {
+		return &ZapLogger{
+			logger: l.logger,
+			group:  append(slices.Clip(l.group), zap.Namespace(name)),
+			prefix: l.prefix,
+			name:   l.name,
+			skip:   l.skip,
+			Config: l.Config,
+		}
+	}
+	return &ZapLogger{
+		logger: l.logger,
+		group:  l.group,
+		prefix: l.prefix + name + ".",
+		name:   l.name,
+		skip:   l.skip,
+		Config: l.Config,
+	}
+}
+
+// Named returns a child logger named after its parent's name joined with name.
+// The name is emitted by zap under Config.NameField.
+func (l *ZapLogger) Named(name string) log.Logger {
+	if name == "" {
+		return l
+	}
+	return &ZapLogger{
+		logger: l.logger.Named(name),
+		group:  l.group,
+		prefix: l.prefix,
+		name:   log.JoinName(l.name, name),
+		skip:   l.skip,
+		Config: l.Config,
+	}
+}
+
+// Enabled reports whether the logger writes entries at level.
+func (l *ZapLogger) Enabled(ctx context.Context, level log.Level) bool {
+	return l.LevelFor(l.name) <= level
+}
+
+// WithCallerSkip returns a child logger that skips skip additional stack frames.
+func (l *ZapLogger) WithCallerSkip(skip int) log.Logger {
+	return &ZapLogger{
+		logger: l.logger,
+		group:  l.group,
+		prefix: l.prefix,
+		name:   l.name,
+		skip:   l.skip + skip,
+		Config: l.Config,
+	}
+}
+
+// Panic logs a message at Panic level if enabled, flushes the outputs and then panics.
+func (l *ZapLogger) Panic(ctx context.Context, msg string, keysAndValues ...any) {
+	if l.Enabled(ctx, log.Panic) {
+		l.log(ctx, log.Panic, msg, keysAndValues, nil)
+	}
+	_ = l.Sync()
+	panic(msg)
+}
+
+// LogFields logs fields at level without converting them to interfaces, see
+// log.FieldLogger.
+func (l *ZapLogger) LogFields(ctx context.Context, level log.Level, msg string, fields ...log.Field) {
+	if l.Enabled(ctx, level) {
+		l.log(ctx, level, msg, nil, fields)
+	}
+	switch level {
+	case log.Panic:
+		_ = l.Sync()
+		panic(msg)
+	case log.Fatal:
+		_ = l.Sync()
+		log.Exit(l.ExitFunc, 1)
+	}
+}
+
+// Fatal logs a message at Fatal level if enabled, flushes the outputs and then calls log.Exit with Config.ExitFunc.
 func (l *ZapLogger) Fatal(ctx context.Context, msg string, keysAndValues ...any) {
-	l.logger.Fatal(msg, convertToZapFields(l.stacktrace(log.Error, keysAndValues)...)...)
+	if l.Enabled(ctx, log.Fatal) {
+		l.log(ctx, log.Fatal, msg, keysAndValues, nil)
+	}
+	_ = l.Sync()
+	log.Exit(l.ExitFunc, 1)
+}
+
+// Sync flushes the outputs by calling zap.Logger.Sync, see log.SyncOutputs.
+func (l *ZapLogger) Sync() error {
+	return l.logger.Sync()
+}
+
+// Close flushes and closes the outputs, see log.CloseOutputs. Zap writes to the
+// outputs without buffering, so flushing the outputs flushes all entries.
+func (l *ZapLogger) Close() error {
+	return log.CloseOutputs(l.Outputs)
+}
+
+// continueHook is a zapcore.CheckWriteHook that continues after writing an entry.
+// Unlike zapcore.WriteThenNoop, zap accepts it for Panic and Fatal levels.
+type continueHook struct{}
+
+// OnWrite implements zapcore.CheckWriteHook.
+func (continueHook) OnWrite(*zapcore.CheckedEntry, []zapcore.Field) {}
+
+// outputSyncer is a zapcore.WriteSyncer that flushes an output with log.SyncOutputs,
+// which ignores the errors of syncing os.Stdout and os.Stderr.
+type outputSyncer struct {
+	io.Writer
+}
+
+// Sync implements zapcore.WriteSyncer.
+func (w outputSyncer) Sync() error {
+	return log.SyncOutputs([]io.Writer{w.Writer})
 }
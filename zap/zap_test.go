@@ -3,11 +3,22 @@ package zap
 import (
 	"bytes"
 	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
 	"io"
+	stdlog "log"
+	"os"
+	"path/filepath"
+	"reflect"
+	"runtime"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/prakashpandey/golog/log"
 	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
 )
 
 func TestNewZapLogger(t *testing.T) {
@@ -122,7 +133,9 @@ func TestConvertToZapFields(t *testing.T) {
 				"key2", 2,
 			},
 			expectedFields: []zap.Field{
-				zap.Any("key2", 2),
+				zap.Any(log.BadKey, 123),
+				zap.Any("value1", "key2"),
+				zap.Any(log.BadKey, 2),
 			},
 		},
 		{
@@ -133,6 +146,7 @@ func TestConvertToZapFields(t *testing.T) {
 			},
 			expectedFields: []zap.Field{
 				zap.Any("key1", "value1"),
+				zap.String("key2", log.MissingValue),
 			},
 		},
 	}
@@ -141,13 +155,701 @@ func TestConvertToZapFields(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			fields := convertToZapFields(tt.keysAndValues...)
 			if len(fields) != len(tt.expectedFields) {
-				t.Errorf("Expected %d fields, got %d", len(tt.expectedFields), len(fields))
+				t.Fatalf("Expected %d fields, got %d", len(tt.expectedFields), len(fields))
 			}
 			for i, field := range fields {
-				if field.Key != tt.expectedFields[i].Key || field.Interface != tt.expectedFields[i].Interface {
+				if !field.Equals(tt.expectedFields[i]) {
 					t.Errorf("Expected field %v, got %v", tt.expectedFields[i], field)
 				}
 			}
 		})
 	}
 }
+
+func TestZapLogger_With(t *testing.T) {
+	var buf bytes.Buffer
+	config := log.Config{
+		Outputs:      []io.Writer{&buf},
+		OutputFormat: log.OutputFormatJSON,
+		LogLevel:     log.Info,
+	}
+
+	logger := NewZapLogger(config)
+	ctx := context.Background()
+	child := logger.With("request_id", "abc123")
+
+	child.Info(ctx, "Child message")
+	logger.Info(ctx, "Parent message")
+
+	lines := bytes.Split(bytes.TrimSpace(buf.Bytes()), []byte("\n"))
+	if len(lines) != 2 {
+		t.Fatalf("Expected 2 log lines, got %d: %s", len(lines), buf.String())
+	}
+	if !bytes.Contains(lines[0], []byte(`"request_id":"abc123"`)) {
+		t.Errorf("Expected child entry to contain request_id, got: %s", lines[0])
+	}
+	if bytes.Contains(lines[1], []byte("request_id")) {
+		t.Errorf("Expected parent entry to not contain request_id, got: %s", lines[1])
+	}
+}
+
+func TestZapLogger_WithGroup(t *testing.T) {
+	tests := []struct {
+		format   log.OutputFormat
+		expected string
+	}{
+		{log.OutputFormatJSON, `"http":{"method":"GET","status":200}`},
+		{log.OutputFormatTEXT, `{"http.method": "GET", "http.status": 200}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.format), func(t *testing.T) {
+			var buf bytes.Buffer
+			config := log.Config{
+				Outputs:      []io.Writer{&buf},
+				OutputFormat: tt.format,
+				LogLevel:     log.Info,
+			}
+
+			logger := NewZapLogger(config).WithGroup("http").With("method", "GET")
+			logger.Info(context.Background(), "Request served", "status", 200)
+
+			if !bytes.Contains(buf.Bytes(), []byte(tt.expected)) {
+				t.Errorf("Expected %q in log output, got: %s", tt.expected, buf.String())
+			}
+		})
+	}
+}
+
+// TestZapLogger_WithGroup_Metadata tests that caller and stack trace information stay at the
+// top level of grouped entries.
+func TestZapLogger_WithGroup_Metadata(t *testing.T) {
+	for _, format := range []log.OutputFormat{log.OutputFormatJSON, log.OutputFormatTEXT} {
+		var buf bytes.Buffer
+		config := log.Config{
+			Outputs:      []io.Writer{&buf},
+			OutputFormat: format,
+			Caller:       log.Caller{Enabled: true},
+			Stacktrace:   log.Stacktrace{Enabled: true, Level: log.Info},
+		}
+
+		NewZapLogger(config).WithGroup("http").With("method", "GET").Info(context.Background(), "Request served", "status", 200)
+
+		out := buf.String()
+		if format == log.OutputFormatJSON {
+			var entry map[string]any
+			if err := json.Unmarshal([]byte(out), &entry); err != nil {
+				t.Fatalf("Failed to parse log output %s: %v", out, err)
+			}
+			group, _ := entry["http"].(map[string]any)
+			if entry["caller"] == nil || entry["stacktrace"] == nil || group["caller"] != nil || group["stacktrace"] != nil {
+				t.Errorf("Expected caller and stack trace at the top level, got: %s", out)
+			}
+			if group["method"] != "GET" || group["status"] != float64(200) {
+				t.Errorf("Expected the fields in the group, got: %s", out)
+			}
+			continue
+		}
+		if !strings.Contains(out, "caller") || strings.Contains(out, "http.caller") || strings.Contains(out, "http.stacktrace") {
+			t.Errorf("Expected caller and stack trace at the top level in %s output, got: %s", format, out)
+		}
+	}
+}
+
+// TestZapLogger_Named_WithGroup tests that the logger name stays at the top level of
+// grouped entries.
+func TestZapLogger_Named_WithGroup(t *testing.T) {
+	var buf bytes.Buffer
+	logger := NewZapLogger(log.Config{Outputs: []io.Writer{&buf}, OutputFormat: log.OutputFormatJSON})
+
+	logger.Named("db").WithGroup("http").Info(context.Background(), "Request served", "status", 200)
+
+	var entry map[string]any
+	if err := json.Unmarshal([]byte(buf.String()), &entry); err != nil {
+		t.Fatalf("Failed to parse log output %s: %v", buf.String(), err)
+	}
+	if group, _ := entry["http"].(map[string]any); entry["logger"] != "db" || group["logger"] != nil {
+		t.Errorf("Expected the logger name at the top level, got: %s", buf.String())
+	}
+}
+
+func TestZapLogger_Named(t *testing.T) {
+	var buf bytes.Buffer
+	config := log.Config{
+		Outputs:      []io.Writer{&buf},
+		OutputFormat: log.OutputFormatJSON,
+		LogLevel:     log.Warn,
+		NamedLevels:  log.NewNamedLevels(map[string]log.Level{"db": log.Info}),
+	}
+
+	logger := NewZapLogger(config)
+	ctx := context.Background()
+	logger.Named("db").Named("pool").Info(ctx, "Pool message")
+	logger.Named("http").Info(ctx, "HTTP message")
+
+	if !bytes.Contains(buf.Bytes(), []byte(`"logger":"db.pool"`)) {
+		t.Errorf("Expected logger name db.pool in log output, got: %s", buf.String())
+	}
+	if !bytes.Contains(buf.Bytes(), []byte("Pool message")) {
+		t.Errorf("Expected 'Pool message' in log output, but it was not logged")
+	}
+	if bytes.Contains(buf.Bytes(), []byte("HTTP message")) {
+		t.Errorf("Expected 'HTTP message' to be filtered out, but it was logged")
+	}
+}
+
+func TestZapLogger_ContextFields(t *testing.T) {
+	var buf bytes.Buffer
+	config := log.Config{
+		Outputs:      []io.Writer{&buf},
+		OutputFormat: log.OutputFormatJSON,
+		LogLevel:     log.Info,
+	}
+
+	logger := NewZapLogger(config)
+	ctx := log.ContextWithFields(context.Background(), "request_id", "abc123")
+	logger.Info(ctx, "Request message", "key", "value")
+
+	if !bytes.Contains(buf.Bytes(), []byte(`"request_id":"abc123","key":"value"`)) {
+		t.Errorf("Expected context fields in log output, got: %s", buf.String())
+	}
+}
+
+func TestZapLogger_AtomicLevel(t *testing.T) {
+	var buf bytes.Buffer
+	level := log.NewAtomicLevel(log.Error)
+	config := log.Config{
+		Outputs:      []io.Writer{&buf},
+		OutputFormat: log.OutputFormatJSON,
+		Level:        level,
+	}
+
+	logger := NewZapLogger(config)
+	ctx := context.Background()
+	logger.Warn(ctx, "Before change")
+	level.SetLevel(log.Warn)
+	logger.Warn(ctx, "After change")
+
+	if bytes.Contains(buf.Bytes(), []byte("Before change")) {
+		t.Errorf("Expected 'Before change' to be filtered out, but it was logged")
+	}
+	if !bytes.Contains(buf.Bytes(), []byte("After change")) {
+		t.Errorf("Expected 'After change' in log output, but it was not logged")
+	}
+}
+
+func TestZapLogger_AtomicLevel_Core(t *testing.T) {
+	level := log.NewAtomicLevel(log.Error)
+	named := log.NewNamedLevels(nil)
+	config := log.Config{
+		Outputs:     []io.Writer{io.Discard},
+		Level:       level,
+		NamedLevels: named,
+	}
+
+	core := NewZapLogger(config).(*ZapLogger).logger.Core()
+	if core.Enabled(zapcore.WarnLevel) {
+		t.Errorf("Expected the core to disable warn at level error")
+	}
+	level.SetLevel(log.Notice)
+	if core.Enabled(zapcore.InfoLevel) || !core.Enabled(noticeLevel) || !core.Enabled(zapcore.WarnLevel) {
+		t.Errorf("Expected the core to enable notice and above at level notice")
+	}
+	named.SetLevel("db", log.Debug)
+	if !core.Enabled(zapcore.DebugLevel) || core.Enabled(traceLevel) {
+		t.Errorf("Expected the core to enable debug and above for a named logger at level debug")
+	}
+	named.Unset("db")
+	level.SetLevel(log.Off)
+	if core.Enabled(zapcore.FatalLevel) {
+		t.Errorf("Expected the core to disable every level at level off")
+	}
+}
+
+func TestZapLogger_Caller(t *testing.T) {
+	var buf bytes.Buffer
+	config := log.Config{
+		Outputs:      []io.Writer{&buf},
+		OutputFormat: log.OutputFormatJSON,
+		LogLevel:     log.Info,
+		Caller:       log.Caller{Enabled: true},
+	}
+
+	logger := NewZapLogger(config)
+	ctx := context.Background()
+
+	logger.Info(ctx, "Direct message")
+	_, file, line, _ := runtime.Caller(0)
+	if expected := fmt.Sprintf("%s:%d", file, line-1); !bytes.Contains(buf.Bytes(), []byte(expected)) {
+		t.Errorf("Expected caller %q in log output, got: %s", expected, buf.String())
+	}
+
+	buf.Reset()
+	log.NewSugar(logger).Infof(ctx, "Sugared %s", "message")
+	_, file, line, _ = runtime.Caller(0)
+	if expected := fmt.Sprintf("%s:%d", file, line-1); !bytes.Contains(buf.Bytes(), []byte(expected)) {
+		t.Errorf("Expected caller %q in log output, got: %s", expected, buf.String())
+	}
+}
+
+func TestZapLogger_ExtendedLevels(t *testing.T) {
+	var buf bytes.Buffer
+	config := log.Config{
+		Outputs:      []io.Writer{&buf},
+		OutputFormat: log.OutputFormatJSON,
+		Level:        log.NewAtomicLevel(log.Trace),
+	}
+
+	logger := NewZapLogger(config)
+	ctx := context.Background()
+	logger.Trace(ctx, "Trace message")
+	logger.Notice(ctx, "Notice message")
+	logger.Critical(ctx, "Critical message")
+
+	for _, expected := range []string{`"level":"trace"`, `"level":"notice"`, `"level":"critical"`} {
+		if !bytes.Contains(buf.Bytes(), []byte(expected)) {
+			t.Errorf("Expected %s in log output, got: %s", expected, buf.String())
+		}
+	}
+}
+
+func TestZapLogger_Panic(t *testing.T) {
+	var buf bytes.Buffer
+	config := log.Config{
+		Outputs:      []io.Writer{&buf},
+		OutputFormat: log.OutputFormatJSON,
+		LogLevel:     log.Info,
+	}
+
+	logger := NewZapLogger(config)
+	defer func() {
+		if r := recover(); r != "Panic message" {
+			t.Errorf("Expected panic with 'Panic message', got: %v", r)
+		}
+		if !bytes.Contains(buf.Bytes(), []byte(`"level":"panic"`)) {
+			t.Errorf("Expected panic entry in log output, got: %s", buf.String())
+		}
+	}()
+	logger.Panic(context.Background(), "Panic message")
+}
+
+func TestZapLogger_Off(t *testing.T) {
+	var buf bytes.Buffer
+	config := log.Config{
+		Outputs:      []io.Writer{&buf},
+		OutputFormat: log.OutputFormatJSON,
+		LogLevel:     log.Off,
+	}
+
+	logger := NewZapLogger(config)
+	logger.Critical(context.Background(), "Critical message")
+
+	if buf.Len() != 0 {
+		t.Errorf("Expected no log output, got: %s", buf.String())
+	}
+}
+
+func TestZapLogger_LiveAttrs(t *testing.T) {
+	var buf bytes.Buffer
+	attrs := log.NewAtomicAttrs(map[string]string{"version": "1"})
+	config := log.Config{
+		Outputs:      []io.Writer{&buf},
+		OutputFormat: log.OutputFormatJSON,
+		LogLevel:     log.Info,
+		LiveAttrs:    attrs,
+	}
+
+	logger := NewZapLogger(config)
+	ctx := context.Background()
+	logger.Info(ctx, "First message")
+	attrs.Store(map[string]string{"version": "2"})
+	logger.Info(ctx, "Second message")
+
+	lines := bytes.Split(bytes.TrimSpace(buf.Bytes()), []byte("\n"))
+	if len(lines) != 2 || !bytes.Contains(lines[0], []byte(`"version":"1"`)) || !bytes.Contains(lines[1], []byte(`"version":"2"`)) {
+		t.Errorf("Expected live attrs in log output, got: %s", buf.String())
+	}
+}
+
+func TestNew(t *testing.T) {
+	var buf bytes.Buffer
+	logger, err := New(log.Config{Outputs: []io.Writer{&buf}})
+	if err != nil || logger == nil {
+		t.Fatalf("New returned an unexpected error: %v", err)
+	}
+
+	logger, err = New(log.Config{OutputFormat: "XML"})
+	if err == nil || logger != nil {
+		t.Fatalf("Expected New to fail for an invalid config, got %v", logger)
+	}
+	for _, field := range []string{"OutputFormat", "Outputs"} {
+		if !bytes.Contains([]byte(err.Error()), []byte(field)) {
+			t.Errorf("Expected error to mention %s, got: %v", field, err)
+		}
+	}
+}
+
+func TestBackendRegistered(t *testing.T) {
+	config := log.Config{Backend: BackendName, Outputs: []io.Writer{&bytes.Buffer{}}}
+	logger, err := log.New("", config)
+	if err != nil {
+		t.Fatalf("log.New returned an unexpected error: %v", err)
+	}
+	if _, ok := logger.(*ZapLogger); !ok {
+		t.Errorf("Expected a ZapLogger, got %T", logger)
+	}
+	if _, err := log.New(BackendName, log.Config{}); err == nil {
+		t.Errorf("Expected log.New to validate the config")
+	}
+}
+
+func TestDefault_PackageFunctionsCaller(t *testing.T) {
+	var buf bytes.Buffer
+	config := log.Config{
+		Outputs:      []io.Writer{&buf},
+		OutputFormat: log.OutputFormatJSON,
+		Caller:       log.Caller{Enabled: true},
+	}
+	log.SetDefault(NewZapLogger(config))
+	defer log.SetDefault(nil)
+
+	log.InfoContext(context.Background(), "Package message")
+	_, file, line, _ := runtime.Caller(0)
+	if expected := fmt.Sprintf("%s:%d", file, line-1); !bytes.Contains(buf.Bytes(), []byte(expected)) {
+		t.Errorf("Expected caller %q in log output, got: %s", expected, buf.String())
+	}
+}
+
+func TestStdLogBridge_Caller(t *testing.T) {
+	var buf bytes.Buffer
+	config := log.Config{
+		Outputs:      []io.Writer{&buf},
+		OutputFormat: log.OutputFormatJSON,
+		Caller:       log.Caller{Enabled: true},
+	}
+	logger := NewZapLogger(config)
+
+	log.NewStdLogger(logger, log.Warn).Printf("Bridged %s", "message")
+	_, file, line, _ := runtime.Caller(0)
+	if expected := fmt.Sprintf("%s:%d", file, line-1); !bytes.Contains(buf.Bytes(), []byte(expected)) {
+		t.Errorf("Expected caller %q in log output, got: %s", expected, buf.String())
+	}
+
+	buf.Reset()
+	restore := log.RedirectStdLog(logger)
+	stdlog.Print("Redirected message")
+	_, file, line, _ = runtime.Caller(0)
+	restore()
+	if expected := fmt.Sprintf("%s:%d", file, line-1); !bytes.Contains(buf.Bytes(), []byte(expected)) {
+		t.Errorf("Expected caller %q in log output, got: %s", expected, buf.String())
+	}
+}
+
+func TestSyncAndClose(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "app.log")
+	f, err := os.Create(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	logger := NewZapLogger(log.Config{Outputs: []io.Writer{f}})
+
+	logger.Info(context.Background(), "Last message")
+	if err := logger.Sync(); err != nil {
+		t.Errorf("Sync returned an unexpected error: %v", err)
+	}
+	if err := logger.Close(); err != nil {
+		t.Errorf("Close returned an unexpected error: %v", err)
+	}
+	if _, err := f.Write([]byte("x")); !errors.Is(err, os.ErrClosed) {
+		t.Errorf("Expected Close to close the file, got %v", err)
+	}
+	if data, _ := os.ReadFile(path); !strings.Contains(string(data), "Last message") {
+		t.Errorf("Expected the entry in the file, got: %s", data)
+	}
+}
+
+func TestFatal_ExitFunc(t *testing.T) {
+	var buf bytes.Buffer
+	config := log.Config{
+		Outputs:      []io.Writer{&buf},
+		OutputFormat: log.OutputFormatJSON,
+		ExitFunc:     log.PanicOnFatal,
+	}
+	hooked := false
+	remove := log.OnFatal(func() { hooked = true })
+	defer remove()
+
+	logger := NewZapLogger(config)
+	defer func() {
+		if r := recover(); r != log.ErrFatal {
+			t.Errorf("Expected panic with log.ErrFatal, got: %v", r)
+		}
+		if !hooked {
+			t.Errorf("Expected the fatal hook to run before exiting")
+		}
+		if !bytes.Contains(buf.Bytes(), []byte(`"level":"fatal"`)) {
+			t.Errorf("Expected fatal entry in log output, got: %s", buf.String())
+		}
+	}()
+	logger.Fatal(context.Background(), "Fatal message")
+}
+
+func TestErrorFields(t *testing.T) {
+	err := fmt.Errorf("load config: %w", io.EOF)
+	for _, format := range []log.OutputFormat{log.OutputFormatJSON, log.OutputFormatTEXT} {
+		var buf bytes.Buffer
+		logger := NewZapLogger(log.Config{Outputs: []io.Writer{&buf}, OutputFormat: format})
+
+		logger.Error(context.Background(), "Failed", log.Err(err), "cause", io.ErrUnexpectedEOF)
+
+		out := buf.String()
+		for _, expected := range []string{"load config: EOF", "*fmt.wrapError", "*errors.errorString", "unexpected EOF", "chain"} {
+			if !strings.Contains(out, expected) {
+				t.Errorf("Expected %q in %s output, got: %s", expected, format, out)
+			}
+		}
+	}
+}
+
+func TestTypedFields(t *testing.T) {
+	var buf bytes.Buffer
+	logger := NewZapLogger(log.Config{Outputs: []io.Writer{&buf}, OutputFormat: log.OutputFormatJSON})
+
+	logger.Info(context.Background(), "Typed",
+		log.String("s", "v"), log.Int("i", 42), log.Float64("f", 1.5), log.Bool("b", true),
+		log.Duration("d", time.Second), log.Time("t", time.Unix(0, 0).UTC()),
+		log.Bytes("bytes", []byte("hi")), log.Object("o", map[string]int{"a": 1}))
+
+	for _, expected := range []string{`"s":"v"`, `"i":42`, `"f":1.5`, `"b":true`, `"d":1`, `"t":0`, `"bytes":"aGk="`, `"o":{"a":1}`} {
+		if !strings.Contains(buf.String(), expected) {
+			t.Errorf("Expected %s in log output, got: %s", expected, buf.String())
+		}
+	}
+}
+
+// TestTypedFields_Allocs checks that the backend converts and encodes typed fields
+// without allocating. The fields are built in advance, since passing them to the
+// Logger allocates.
+func TestLogFields_Allocs(t *testing.T) {
+	if raceEnabled {
+		t.Skip("The race detector drops items from sync.Pool")
+	}
+	logger := NewZapLogger(log.Config{Outputs: []io.Writer{io.Discard}, OutputFormat: log.OutputFormatJSON}).(*ZapLogger)
+	ctx := context.Background()
+	now := time.Now()
+
+	allocs := testing.AllocsPerRun(100, func() {
+		logger.LogFields(ctx, log.Info, "Typed", log.String("s", "v"), log.Int("i", 42), log.Bool("b", true), log.Duration("d", time.Second), log.Time("t", now))
+	})
+	if allocs != 0 {
+		t.Errorf("Expected no allocations, got %v", allocs)
+	}
+}
+
+func TestLogFields(t *testing.T) {
+	var buf bytes.Buffer
+	logger := NewZapLogger(log.Config{Outputs: []io.Writer{&buf}, OutputFormat: log.OutputFormatJSON}).WithGroup("http")
+
+	fl, ok := logger.(log.FieldLogger)
+	if !ok {
+		t.Fatalf("Expected %T to implement log.FieldLogger", logger)
+	}
+	fl.LogFields(context.Background(), log.Warn, "Typed", log.Int("status", 502))
+	fl.LogFields(context.Background(), log.Debug, "Disabled", log.Int("status", 200))
+
+	var entry map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &entry); err != nil {
+		t.Fatalf("Expected a single JSON entry, got %q: %v", buf.String(), err)
+	}
+	if entry["msg"] != "Typed" || !reflect.DeepEqual(entry["http"], map[string]any{"status": float64(502)}) {
+		t.Errorf("Unexpected entry: %v", entry)
+	}
+}
+
+func TestLogFields_Panic(t *testing.T) {
+	logger := NewZapLogger(log.Config{Outputs: []io.Writer{io.Discard}}).(log.FieldLogger)
+	defer func() {
+		if r := recover(); r != "Panic message" {
+			t.Errorf("Expected panic with %q, got %v", "Panic message", r)
+		}
+	}()
+	logger.LogFields(context.Background(), log.Panic, "Panic message")
+}
+
+func BenchmarkZapLogger_TypedFields(b *testing.B) {
+	logger := NewZapLogger(log.Config{Outputs: []io.Writer{io.Discard}, OutputFormat: log.OutputFormatJSON})
+	ctx := context.Background()
+	now := time.Now()
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		logger.Info(ctx, "Typed", log.String("s", "v"), log.Int("i", i), log.Bool("b", true), log.Duration("d", time.Duration(i)), log.Time("t", now))
+	}
+}
+
+func BenchmarkZapLogger_LogFields(b *testing.B) {
+	logger := NewZapLogger(log.Config{Outputs: []io.Writer{io.Discard}, OutputFormat: log.OutputFormatJSON}).(*ZapLogger)
+	ctx := context.Background()
+	now := time.Now()
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		logger.LogFields(ctx, log.Info, "Typed", log.String("s", "v"), log.Int("i", i), log.Bool("b", true), log.Duration("d", time.Duration(i)), log.Time("t", now))
+	}
+}
+
+func BenchmarkZapLogger_KeysAndValues(b *testing.B) {
+	logger := NewZapLogger(log.Config{Outputs: []io.Writer{io.Discard}, OutputFormat: log.OutputFormatJSON})
+	ctx := context.Background()
+	now := time.Now()
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		logger.Info(ctx, "Untyped", "s", "v", "i", i, "b", true, "d", time.Duration(i), "t", now)
+	}
+}
+
+func TestMalformedKeysAndValues(t *testing.T) {
+	var buf bytes.Buffer
+	logger := NewZapLogger(log.Config{Outputs: []io.Writer{&buf}, OutputFormat: log.OutputFormatJSON})
+
+	logger.Info(context.Background(), "Malformed", "a", 1, 42, "b")
+
+	for _, expected := range []string{`"a":1`, `"!BADKEY":42`, `"b":"!MISSING"`} {
+		if !strings.Contains(buf.String(), expected) {
+			t.Errorf("Expected %s in log output, got: %s", expected, buf.String())
+		}
+	}
+}
+
+func TestStrict(t *testing.T) {
+	var errs []error
+	logger := NewZapLogger(log.Config{
+		Outputs:      []io.Writer{io.Discard},
+		OutputFormat: log.OutputFormatJSON,
+		Strict:       true,
+		OnError:      func(err error) { errs = append(errs, err) },
+	})
+	ctx := context.Background()
+
+	_, file, line, _ := runtime.Caller(0)
+	logger.Info(ctx, "Malformed", 42, "v")
+	logger.With("k").Info(ctx, "Well-formed", "a", 1)
+
+	if len(errs) != 2 {
+		t.Fatalf("Expected 2 errors, got %v", errs)
+	}
+	for i, err := range errs {
+		var kvErr *log.KeysAndValuesError
+		if !errors.As(err, &kvErr) {
+			t.Fatalf("Expected a *log.KeysAndValuesError, got %v", err)
+		}
+		if expected := fmt.Sprintf("%s:%d", filepath.Base(file), line+1+i); !strings.Contains(kvErr.Caller, expected) {
+			t.Errorf("Expected caller %s, got %s", expected, kvErr.Caller)
+		}
+	}
+}
+
+// ptrError has an Error method that panics for a nil receiver.
+type ptrError struct{ msg string }
+
+func (e *ptrError) Error() string { return e.msg }
+
+func TestErrorFields_TypedNil(t *testing.T) {
+	var buf bytes.Buffer
+	logger := NewZapLogger(log.Config{Outputs: []io.Writer{&buf}, OutputFormat: log.OutputFormatJSON})
+	var err *ptrError
+
+	logger.Info(context.Background(), "Failed", "err", err, log.Err(err))
+
+	for _, expected := range []string{`"err":"<nil>"`, `"error":"<nil>"`} {
+		if !strings.Contains(buf.String(), expected) {
+			t.Errorf("Expected %s in log output, got: %s", expected, buf.String())
+		}
+	}
+}
+
+// TestZapLogger_LiveAttrs_WithGroup tests that live attributes stay at the top level of
+// grouped entries, like static attributes.
+func TestZapLogger_LiveAttrs_WithGroup(t *testing.T) {
+	var buf bytes.Buffer
+	logger := NewZapLogger(log.Config{
+		Outputs:      []io.Writer{&buf},
+		OutputFormat: log.OutputFormatJSON,
+		Attrs:        map[string]string{"static": "x"},
+		LiveAttrs:    log.NewAtomicAttrs(map[string]string{"service": "api"}),
+	})
+
+	logger.WithGroup("http").Info(context.Background(), "Request served", "status", 200)
+
+	var entry map[string]any
+	if err := json.Unmarshal([]byte(buf.String()), &entry); err != nil {
+		t.Fatalf("Failed to parse log output %s: %v", buf.String(), err)
+	}
+	group, _ := entry["http"].(map[string]any)
+	if entry["static"] != "x" || entry["service"] != "api" || group["service"] != nil {
+		t.Errorf("Expected static and live attributes at the top level, got: %s", buf.String())
+	}
+}
+
+// TestZapLogger_ContextFields_Malformed tests that a malformed keysAndValues does not shift
+// the fields stored in the context.
+func TestZapLogger_ContextFields_Malformed(t *testing.T) {
+	var buf bytes.Buffer
+	logger := NewZapLogger(log.Config{Outputs: []io.Writer{&buf}, OutputFormat: log.OutputFormatJSON})
+	ctx := log.ContextWithFields(context.Background(), "request_id", "abc")
+
+	logger.Info(ctx, "Malformed", "user")
+
+	for _, expected := range []string{`"user":"!MISSING"`, `"request_id":"abc"`} {
+		if !strings.Contains(buf.String(), expected) {
+			t.Errorf("Expected %s in log output, got: %s", expected, buf.String())
+		}
+	}
+}
+
+// TestZapLogger_ContextFields_WithGroup tests that fields stored in the context are logged
+// at the top level rather than in the groups of the logger.
+func TestZapLogger_ContextFields_WithGroup(t *testing.T) {
+	for _, format := range []log.OutputFormat{log.OutputFormatJSON, log.OutputFormatTEXT} {
+		t.Run(string(format), func(t *testing.T) {
+			var buf strings.Builder
+			logger := NewZapLogger(log.Config{Outputs: []io.Writer{&buf}, OutputFormat: format}).WithGroup("http")
+			ctx := log.ContextWithFields(context.Background(), "request_id", "abc")
+
+			logger.Info(ctx, "Grouped", "method", "GET")
+
+			if strings.Contains(buf.String(), "http.request_id") || strings.Count(buf.String(), "request_id") != 1 {
+				t.Errorf("Expected request_id at the top level, got: %s", buf.String())
+			}
+			if format == log.OutputFormatJSON {
+				var entry map[string]any
+				if err := json.Unmarshal([]byte(buf.String()), &entry); err != nil {
+					t.Fatalf("Failed to parse %q: %v", buf.String(), err)
+				}
+				if entry["request_id"] != "abc" || !reflect.DeepEqual(entry["http"], map[string]any{"method": "GET"}) {
+					t.Errorf("Expected request_id at the top level, got: %s", buf.String())
+				}
+			}
+		})
+	}
+}
+
+// TestZapLogger_WithGroup_Empty tests that groups without fields are omitted.
+func TestZapLogger_WithGroup_Empty(t *testing.T) {
+	var buf strings.Builder
+	logger := NewZapLogger(log.Config{Outputs: []io.Writer{&buf}, OutputFormat: log.OutputFormatJSON})
+
+	logger.WithGroup("http").Info(context.Background(), "Empty group")
+	logger.WithGroup("http").With("method", "GET").WithGroup("request").Info(context.Background(), "Empty nested group")
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("Expected 2 entries, got: %s", buf.String())
+	}
+	var entry map[string]any
+	if err := json.Unmarshal([]byte(lines[0]), &entry); err != nil || entry["http"] != nil {
+		t.Errorf("Expected no http group, got: %s", lines[0])
+	}
+	entry = nil
+	if err := json.Unmarshal([]byte(lines[1]), &entry); err != nil || !reflect.DeepEqual(entry["http"], map[string]any{"method": "GET"}) {
+		t.Errorf("Expected an http group without the request group, got: %s", lines[1])
+	}
+}